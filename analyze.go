@@ -0,0 +1,127 @@
+package rid
+
+import (
+	"math/bits"
+	"slices"
+	"time"
+)
+
+// Analyzer measures how far a stream of IDs, consumed in arrival order,
+// departs from timestamp order.
+//
+// An ID arriving after one with a later timestamp is an inversion. Its
+// displacement is the number of earlier arrivals with a later timestamp, i.e.
+// how many positions it must move back to restore order, and its lag is how
+// far its timestamp trails the newest timestamp seen. The maximum
+// displacement is the k for which the stream is k-sorted.
+//
+// Ordering is by timestamp only: IDs within the same second are unordered by
+// design. Memory use grows with the number of distinct seconds seen.
+//
+// An Analyzer is not safe for concurrent use.
+type Analyzer struct {
+	worstN int
+	newest int64
+	counts map[int64]int // arrivals per timestamp
+	report AnalyzerReport
+}
+
+// AnalyzerReport summarises the IDs consumed by an Analyzer.
+type AnalyzerReport struct {
+	Count           int           // IDs consumed
+	Inversions      int           // IDs arriving after one with a later timestamp
+	MaxDisplacement int           // the k in k-sorted
+	MaxLag          time.Duration // largest lag of an inversion
+	// LagHistogram counts inversions by lag: index i counts lags of
+	// [2^i, 2^(i+1)) seconds.
+	LagHistogram []int
+	// Worst holds the inversions with the largest displacement, largest
+	// first.
+	Worst []AnalyzerOffender
+}
+
+// AnalyzerOffender describes an ID that arrived out of order.
+type AnalyzerOffender struct {
+	ID           ID
+	Position     int           // zero-based arrival position
+	Displacement int           // earlier arrivals with a later timestamp
+	Lag          time.Duration // behind the newest timestamp seen
+}
+
+// NewAnalyzer returns an Analyzer reporting up to worst offenders.
+func NewAnalyzer(worst int) *Analyzer {
+	return &Analyzer{
+		worstN: max(0, worst),
+		newest: -1,
+		counts: make(map[int64]int),
+	}
+}
+
+// Add consumes the next ID in arrival order.
+func (a *Analyzer) Add(id ID) {
+	ts := id.Timestamp()
+	pos := a.report.Count
+	a.report.Count++
+	a.counts[ts]++
+
+	if ts >= a.newest {
+		a.newest = ts
+		return
+	}
+
+	lag := a.newest - ts
+	displacement := 0
+	if lag <= int64(len(a.counts)) {
+		for t := ts + 1; t <= a.newest; t++ {
+			displacement += a.counts[t]
+		}
+	} else {
+		for t, n := range a.counts {
+			if t > ts {
+				displacement += n
+			}
+		}
+	}
+
+	r := &a.report
+	r.Inversions++
+	r.MaxDisplacement = max(r.MaxDisplacement, displacement)
+	r.MaxLag = max(r.MaxLag, time.Duration(lag)*time.Second)
+	bucket := bits.Len64(uint64(lag)) - 1
+	for len(r.LagHistogram) <= bucket {
+		r.LagHistogram = append(r.LagHistogram, 0)
+	}
+	r.LagHistogram[bucket]++
+
+	a.addWorst(AnalyzerOffender{ID: id, Position: pos, Displacement: displacement, Lag: time.Duration(lag) * time.Second})
+}
+
+// addWorst keeps the worstN offenders with the largest displacement, in
+// descending order; the earliest arrival wins ties.
+func (a *Analyzer) addWorst(o AnalyzerOffender) {
+	w := a.report.Worst
+	if a.worstN == 0 || (len(w) == a.worstN && w[len(w)-1].Displacement >= o.Displacement) {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(w, o.Displacement, func(e AnalyzerOffender, d int) int {
+		// descending; equal displacements sort before the new offender
+		if e.Displacement >= d {
+			return -1
+		}
+		return 1
+	})
+	w = slices.Insert(w, i, o)
+	if len(w) > a.worstN {
+		w = w[:a.worstN]
+	}
+	a.report.Worst = w
+}
+
+// Report returns a summary of the IDs consumed so far.
+func (a *Analyzer) Report() AnalyzerReport {
+	r := a.report
+	r.LagHistogram = slices.Clone(r.LagHistogram)
+	r.Worst = slices.Clone(r.Worst)
+
+	return r
+}
@@ -0,0 +1,68 @@
+package rid
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnalyzer(t *testing.T) {
+	base := time.Unix(1672246995, 0)
+	at := func(sec int) ID { return NewWithTime(base.Add(time.Duration(sec) * time.Second)) }
+
+	late := at(1)
+	worst := at(0)
+	stream := []ID{
+		at(0), at(2), at(3), at(3), // in order
+		late,                         // behind 2, 3, 3: displacement 3, lag 2s
+		at(4),                        // in order
+		at(3),                        // behind 4: displacement 1, lag 1s
+		at(5), at(10), at(9), at(10), // at(9): displacement 1, lag 1s
+		worst, // behind everything but at(0): displacement 10, lag 10s
+	}
+	a := NewAnalyzer(2)
+	for _, id := range stream {
+		a.Add(id)
+	}
+	got := a.Report()
+	want := AnalyzerReport{
+		Count:           len(stream),
+		Inversions:      4,
+		MaxDisplacement: 10,
+		MaxLag:          10 * time.Second,
+		LagHistogram:    []int{2, 1, 0, 1}, // 1s, 1s | 2s | | 10s
+		Worst: []AnalyzerOffender{
+			{ID: worst, Position: 11, Displacement: 10, Lag: 10 * time.Second},
+			{ID: late, Position: 4, Displacement: 3, Lag: 2 * time.Second},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Report() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestAnalyzerSorted(t *testing.T) {
+	ids := randomIDs(1000, 100)
+	SortFast(ids)
+	a := NewAnalyzer(10)
+	for _, id := range ids {
+		a.Add(id)
+	}
+	r := a.Report()
+	if r.Count != 1000 || r.Inversions != 0 || r.MaxDisplacement != 0 || len(r.Worst) != 0 || len(r.LagHistogram) != 0 {
+		t.Errorf("Report() of sorted stream = %+v", r)
+	}
+}
+
+func TestAnalyzerLargeLag(t *testing.T) {
+	// a lag far exceeding the number of distinct seconds seen
+	a := NewAnalyzer(0)
+	a.Add(IDs[2].id)
+	a.Add(IDs[1].id)
+	a.Add(IDs[1].id)
+	a.Add(IDs[0].id)
+	r := a.Report()
+	if r.MaxDisplacement != 2 || r.Inversions != 1 || r.Worst != nil {
+		t.Errorf("Report() = %+v", r)
+	}
+}
@@ -0,0 +1,90 @@
+package rid
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+// BinaryID is an ID stored in SQL databases in its compact 10-byte binary
+// form (BLOB, bytea) rather than as a 16-character Base32 string.
+//
+// Scan accepts both binary and text forms, so a column can be migrated from
+// TEXT to binary storage without downtime. JSON and text encoding remain
+// Base32, identical to ID.
+//
+//	type Record struct {
+//		ID rid.BinaryID `db:"id"`
+//	}
+//	rec := Record{ID: rid.BinaryID(rid.New())}
+type BinaryID ID
+
+// ID returns b as an ID.
+func (b BinaryID) ID() ID {
+	return ID(b)
+}
+
+// IsNil returns true if b is the zero value.
+func (b BinaryID) IsNil() bool {
+	return ID(b).IsNil()
+}
+
+// String returns b Base32 encoded; see ID.String.
+func (b BinaryID) String() string {
+	return ID(b).String()
+}
+
+// Bytes returns the binary representation of b.
+func (b BinaryID) Bytes() []byte {
+	return b[:]
+}
+
+// Timestamp returns the timestamp component as seconds since the Unix epoch.
+func (b BinaryID) Timestamp() int64 {
+	return ID(b).Timestamp()
+}
+
+// Time returns the timestamp component as a Time value.
+func (b BinaryID) Time() time.Time {
+	return ID(b).Time()
+}
+
+// Value implements package sql's driver.Valuer, returning the 10-byte binary
+// form of b as a []byte.
+// https://golang.org/pkg/database/sql/driver/#Valuer
+func (b BinaryID) Value() (driver.Value, error) {
+	if b.IsNil() {
+		return nil, nil
+	}
+
+	v := make([]byte, rawLen)
+	copy(v, b[:])
+
+	return v, nil
+}
+
+// Scan implements the sql.Scanner interface, accepting the 10-byte binary
+// form as well as the Base32 text form.
+// https://golang.org/pkg/database/sql/#Scanner
+func (b *BinaryID) Scan(value interface{}) error {
+	return (*ID)(b).Scan(value)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b BinaryID) MarshalText() ([]byte, error) {
+	return ID(b).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *BinaryID) UnmarshalText(text []byte) error {
+	return (*ID)(b).UnmarshalText(text)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b BinaryID) MarshalJSON() ([]byte, error) {
+	return ID(b).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *BinaryID) UnmarshalJSON(data []byte) error {
+	return (*ID)(b).UnmarshalJSON(data)
+}
@@ -0,0 +1,98 @@
+package rid
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestBinaryIDDriverValue(t *testing.T) {
+	// dfp7emzzzzy30ey2 ts:1672246995 rnd:281474912761794 2022-12-28 09:03:15 -0800 PST ID{0x63,0xac,0x76,0xd3,0xff,0xff,0xfc,0x30,0x37,0xc2}
+	id := BinaryID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+	got, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok := got.([]byte)
+	if !ok {
+		t.Fatalf("Value() type = %T, want []byte", got)
+	}
+	if !bytes.Equal(b, id[:]) {
+		t.Errorf("Value() = %v, want %v", b, id[:])
+	}
+	// nil
+	got, err = BinaryID{}.Value()
+	if err != nil || got != nil {
+		t.Errorf("Value() = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestBinaryIDDriverScan(t *testing.T) {
+	want := BinaryID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"binary", []byte{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}},
+		{"text bytes", []byte("dfp7emzzzzy30ey2")},
+		{"text string", "dfp7emzzzzy30ey2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got BinaryID
+			if err := got.Scan(tt.value); err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("Scan() = %v, want %v", got, want)
+			}
+		})
+	}
+	var got BinaryID
+	if err := got.Scan([]byte{0x1, 0x2}); err != ErrInvalidID {
+		t.Errorf("Scan() err=%v, want %v", err, ErrInvalidID)
+	}
+	got = BinaryID(New())
+	if err := got.Scan(nil); err != nil || !got.IsNil() {
+		t.Errorf("Scan(nil) = %v, %v, want nil ID", got, err)
+	}
+}
+
+func TestBinaryIDRoundTrip(t *testing.T) {
+	id := BinaryID(New())
+	v, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got BinaryID
+	if err := got.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("Scan(Value()) = %v, want %v", got, id)
+	}
+	if got.ID() != ID(id) || got.String() != ID(id).String() {
+		t.Errorf("ID() = %v, want %v", got.ID(), ID(id))
+	}
+}
+
+func TestBinaryIDJSON(t *testing.T) {
+	type record struct {
+		ID BinaryID
+	}
+	in := record{ID: BinaryID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"ID":"dfp7emzzzzy30ey2"}`; got != want {
+		t.Errorf("json.Marshal() = %v, want %v", got, want)
+	}
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal() = %v, want %v", out, in)
+	}
+}
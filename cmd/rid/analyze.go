@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// analyzeCmd reports how far a stream of IDs read from stdin, in arrival
+// order, departs from timestamp order.
+func analyzeCmd(args []string) int {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	worst := fs.Int("n", 10, "Number of worst offenders to report")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid analyze [-n N] < ids.txt\n\n")
+		fmt.Fprintf(fs.Output(), "Reads IDs, one per line in arrival order, and reports how k-sorted they are.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	status := 0
+	a := rid.NewAnalyzer(*worst)
+	err := scanIDs(os.Stdin, func(line int, text string, id rid.ID, err error) bool {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: [%s] %s\n", line, text, err)
+			status = 1
+			return true
+		}
+		a.Add(id)
+		return true
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+
+	r := a.Report()
+	fmt.Printf("IDs:              %d\n", r.Count)
+	fmt.Printf("Inversions:       %d\n", r.Inversions)
+	fmt.Printf("Max displacement: %d (k)\n", r.MaxDisplacement)
+	fmt.Printf("Max lag:          %s\n", r.MaxLag)
+	if len(r.LagHistogram) > 0 {
+		fmt.Printf("\nInversions by lag:\n")
+		for i, n := range r.LagHistogram {
+			if n == 0 {
+				continue
+			}
+			lo, hi := time.Duration(1<<i)*time.Second, time.Duration(1<<(i+1))*time.Second
+			fmt.Printf("  %12s - %-12s %d\n", lo, hi, n)
+		}
+	}
+	if len(r.Worst) > 0 {
+		fmt.Printf("\nWorst offenders:\n")
+		for _, o := range r.Worst {
+			fmt.Printf("  %s arrival:%d displacement:%d lag:%s\n", o.ID, o.Position+1, o.Displacement, o.Lag)
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mwyvr/rid"
+)
+
+// representations lists the values accepted by convert -to
+const representations = "base32, hex, bytes, uuid or go"
+
+var errUnknownForm = errors.New("not a recognised ID representation")
+
+// convertCmd converts IDs between representations.
+func convertCmd(args []string) int {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	to := fs.String("to", "base32", "Target representation: "+representations)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid convert -to FORM [ID...]\n\n")
+		fmt.Fprintf(fs.Output(), "Converts IDs in any supported form, read from the arguments or stdin one per line:\n")
+		fmt.Fprintf(fs.Output(), "  base32  dfp7emzzzzy30ey2\n")
+		fmt.Fprintf(fs.Output(), "  hex     63ac76d3fffffc3037c2, optionally 0x prefixed\n")
+		fmt.Fprintf(fs.Output(), "  bytes   [99 172 118 211 255 255 252 48 55 194]\n")
+		fmt.Fprintf(fs.Output(), "  uuid    63ac76d3-ffff-fc30-37c2-000000000000, the ID zero padded to 16 bytes\n")
+		fmt.Fprintf(fs.Output(), "  go      ID{ 0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2 }\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if _, err := formatAs(rid.NilID(), *to); err != nil {
+		fmt.Fprintf(fs.Output(), "rid: %s\n", err)
+		return 2
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	status := 0
+	convert := func(text string) error {
+		id, err := parseAny(text)
+		if err != nil {
+			return err
+		}
+		s, _ := formatAs(id, *to)
+		fmt.Fprintf(w, "%s\n", s)
+		return nil
+	}
+
+	if len(fs.Args()) > 0 {
+		for _, arg := range fs.Args() {
+			if err := convert(arg); err != nil {
+				fmt.Fprintf(os.Stderr, "[%s] %s\n", arg, err)
+				status = 1
+			}
+		}
+		return status
+	}
+
+	if err := eachLine(os.Stdin, func(line int, text string) bool {
+		if err := convert(text); err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: [%s] %s\n", line, text, err)
+			status = 1
+		}
+		return true
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+
+	return status
+}
+
+// parseAny decodes an ID from any of the representations produced by
+// formatAs.
+func parseAny(s string) (rid.ID, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case len(s) == encodedLen:
+		return rid.FromString(s)
+	case len(s) == 36 && s[8] == '-' && s[13] == '-' && s[18] == '-' && s[23] == '-':
+		b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+		if err != nil {
+			return rid.NilID(), rid.ErrInvalidID
+		}
+		for _, v := range b[10:] {
+			if v != 0 {
+				return rid.NilID(), errors.New("uuid holds more than 10 bytes")
+			}
+		}
+		return rid.FromBytes(b[:10])
+	case strings.ContainsAny(s, "{["):
+		return parseByteList(s)
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	if err != nil {
+		return rid.NilID(), errUnknownForm
+	}
+
+	return rid.FromBytes(b)
+}
+
+// parseByteList decodes a list of byte values such as a Go composite literal,
+// rid.ID{0x63, 0xac, ...} or []byte{99, 172, ...}, or the %v form of a
+// []byte, [99 172 ...].
+func parseByteList(s string) (rid.ID, error) {
+	// a composite literal's type may itself hold brackets, as in []byte{...}
+	opening, closing := "[", "]"
+	if strings.Contains(s, "{") {
+		opening, closing = "{", "}"
+	}
+	s = s[strings.Index(s, opening)+1:]
+	end := strings.Index(s, closing)
+	if end < 0 {
+		return rid.NilID(), errUnknownForm
+	}
+
+	var b []byte
+	fields := strings.FieldsFunc(s[:end], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	for _, f := range fields {
+		v, err := strconv.ParseUint(f, 0, 8)
+		if err != nil {
+			return rid.NilID(), fmt.Errorf("invalid byte %q", f)
+		}
+		b = append(b, byte(v))
+	}
+
+	return rid.FromBytes(b)
+}
+
+// formatAs returns id in the named representation.
+func formatAs(id rid.ID, form string) (string, error) {
+	switch form {
+	case "base32":
+		return id.String(), nil
+	case "hex":
+		return hex.EncodeToString(id.Bytes()), nil
+	case "bytes":
+		return fmt.Sprint(id.Bytes()), nil
+	case "uuid":
+		h := hex.EncodeToString(id.Bytes()) + "000000000000"
+		return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+	case "go":
+		return fmt.Sprintf("ID{%s }", asHex(id.Bytes())), nil
+	}
+
+	return "", fmt.Errorf("unknown representation %q, want %s", form, representations)
+}
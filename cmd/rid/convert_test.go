@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+func TestFormatAs(t *testing.T) {
+	id, err := rid.FromString("dfp7emzzzzy30ey2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		form string
+		want string
+	}{
+		{"base32", "dfp7emzzzzy30ey2"},
+		{"hex", "63ac76d3fffffc3037c2"},
+		{"bytes", "[99 172 118 211 255 255 252 48 55 194]"},
+		{"uuid", "63ac76d3-ffff-fc30-37c2-000000000000"},
+		{"go", "ID{ 0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2 }"},
+	}
+	for _, tt := range tests {
+		got, err := formatAs(id, tt.form)
+		if err != nil || got != tt.want {
+			t.Errorf("formatAs(%q) = %q, %v, want %q", tt.form, got, err, tt.want)
+		}
+	}
+	if _, err := formatAs(id, "base64"); err == nil {
+		t.Error("formatAs(base64) err=nil, want error")
+	}
+}
+
+func TestParseAnyRoundTrip(t *testing.T) {
+	ids := []rid.ID{rid.NilID(), rid.New()}
+	for i := 0; i < 100; i++ {
+		ids = append(ids, rid.New())
+	}
+	for _, id := range ids {
+		for _, form := range []string{"base32", "hex", "bytes", "uuid", "go"} {
+			s, _ := formatAs(id, form)
+			got, err := parseAny(s)
+			if err != nil || got != id {
+				t.Fatalf("parseAny(%q) = %v, %v, want %v", s, got, err, id)
+			}
+		}
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	id, err := rid.FromString("dfp7emzzzzy30ey2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		s   string
+		err bool
+	}{
+		{"  dfp7emzzzzy30ey2\t", false},
+		{"0x63ac76d3fffffc3037c2", false},
+		{"0X63AC76D3FFFFFC3037C2", false},
+		{"63AC76D3-FFFF-FC30-37C2-000000000000", false},
+		{"rid.ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}", false},
+		{"[]byte{99,172,118,211,255,255,252,48,55,194}", false},
+		{"63ac76d3-ffff-fc30-37c2-000000000001", true}, // non-zero padding
+		{"63ac76d3-ffff-fc30-37c2-00000000000g", true},
+		{"63ac76d3fffffc3037", true},                 // 9 bytes
+		{"[99 172 118 211 255 255 252 48 55]", true}, // 9 bytes
+		{"[99 172 118 211 255 255 252 48 55 256]", true},
+		{"[99 172 118 211 255 255 252 48 55 194", true}, // unterminated
+		{"dfp7emzzzzy30eu2", true},                      // u is not in the charset
+		{"zz", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		got, err := parseAny(tt.s)
+		switch {
+		case tt.err && err == nil:
+			t.Errorf("parseAny(%q) = %v, want error", tt.s, got)
+		case !tt.err && (err != nil || got != id):
+			t.Errorf("parseAny(%q) = %v, %v, want %v", tt.s, got, err, id)
+		}
+	}
+}
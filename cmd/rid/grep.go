@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// grepCmd finds IDs in arbitrary text such as logs, printing each with its
+// location and time, or copying the text with each ID's time annotated.
+func grepCmd(args []string) int {
+	fs := flag.NewFlagSet("grep", flag.ExitOnError)
+	annotate := fs.Bool("annotate", false, "Copy the input, adding the time after each ID")
+	timeFormat := timeFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid grep [-annotate] [FILE...]\n\n")
+		fmt.Fprintf(fs.Output(), "Finds IDs in the files or stdin, printing the line, ID and time of each,\n")
+		fmt.Fprintf(fs.Output(), "prefixed by the file name if more than one file is given. Exits 1 if no\n")
+		fmt.Fprintf(fs.Output(), "IDs are found.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	tf, err := timeFormat()
+	if err != nil {
+		fmt.Fprintf(fs.Output(), "rid: %s\n", err)
+		return 2
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	found, failed := false, false
+	for _, name := range files {
+		f, err := openInput(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+			failed = true
+			continue
+		}
+
+		prefix := ""
+		if len(files) > 1 {
+			prefix = name + ":"
+		}
+		err = eachRawLine(f, func(line int, b []byte) bool {
+			if *annotate {
+				last := 0
+				for at, id := range rid.FindAll(b) {
+					end := at + encodedLen
+					w.Write(b[last:end])
+					fmt.Fprintf(w, " (%s)", tf.format(id, time.RFC3339))
+					last = end
+				}
+				w.Write(b[last:])
+				w.Flush() // keep pace with streams such as tail -f
+				return true
+			}
+			for _, id := range rid.FindAll(b) {
+				fmt.Fprintf(w, "%s%d: %s %s\n", prefix, line, id, tf.format(id, time.RFC3339))
+				found = true
+			}
+			return true
+		})
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s: %s\n", name, err)
+			failed = true
+		}
+	}
+
+	if failed || (!found && !*annotate) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mwyvr/rid"
+)
+
+// encodedLen is the length of a Base32 encoded ID
+const encodedLen = 16
+
+// openInput opens the named file for reading, or stdin if name is "-".
+func openInput(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(name)
+}
+
+// eachRawLine calls fn with the line number and bytes of each line of r,
+// including any line ending, so that text can be copied unchanged. Reading
+// stops early if fn returns false.
+func eachRawLine(r io.Reader, fn func(line int, b []byte) bool) error {
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		b, err := br.ReadBytes('\n')
+		if len(b) > 0 && !fn(line, b) {
+			return nil
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// eachLine calls fn with the line number and trimmed text of each non-blank
+// line of r. Reading stops early if fn returns false.
+func eachLine(r io.Reader, fn func(line int, text string) bool) error {
+	return eachRawLine(r, func(line int, b []byte) bool {
+		text := strings.TrimSpace(string(b))
+		if text == "" {
+			return true
+		}
+		return fn(line, text)
+	})
+}
+
+// scanIDs reads Base32 encoded IDs from r, one per line, calling fn with the
+// line number, trimmed text and decoded ID or decoding error of each
+// non-blank line. Scanning stops early if fn returns false.
+func scanIDs(r io.Reader, fn func(line int, text string, id rid.ID, err error) bool) error {
+	return eachLine(r, func(line int, text string) bool {
+		id, err := rid.FromString(text)
+		return fn(line, text, id, err)
+	})
+}
+
+// readIDFile reads a list of Base32 encoded IDs, one per line, from the named
+// file or stdin if name is "-". Blank lines are ignored.
+func readIDFile(name string) ([]rid.ID, error) {
+	f, err := openInput(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ids []rid.ID
+	var lineErr error
+	err = scanIDs(f, func(line int, text string, id rid.ID, err error) bool {
+		if err != nil {
+			lineErr = fmt.Errorf("%s:%d: [%s] %w", name, line, text, err)
+			return false
+		}
+		ids = append(ids, id)
+		return true
+	})
+	if lineErr != nil {
+		return nil, lineErr
+	}
+
+	return ids, err
+}
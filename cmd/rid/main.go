@@ -10,49 +10,143 @@ import (
 	"github.com/mwyvr/rid"
 )
 
+// commands maps subcommand names to their implementation, each returning an
+// exit status
+var commands = map[string]func(args []string) int{
+	"analyze":  analyzeCmd,
+	"convert":  convertCmd,
+	"grep":     grepCmd,
+	"merge":    mergeCmd,
+	"resolve":  resolveCmd,
+	"serve":    serveCmd,
+	"sort":     sortCmd,
+	"stats":    statsCmd,
+	"validate": validateCmd,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			os.Exit(cmd(os.Args[2:]))
+		}
+	}
+
 	count := 1
+	format := "text"
 	flag.IntVar(&count, "c", count, "Generate N-count IDs")
+	flag.StringVar(&format, "format", format, "Output format: "+formats)
+	jsonOut := flag.Bool("json", false, "Output JSON; shorthand for -format json")
+	var at, from, to string
+	flag.StringVar(&at, "t", "", "Generate IDs for a time: RFC 3339, Unix seconds or relative to now like -2h")
+	flag.StringVar(&at, "time", "", "Alias of -t")
+	flag.StringVar(&from, "from", "", "Generate IDs spread evenly from this time")
+	flag.StringVar(&to, "to", "", "Generate IDs spread evenly to this time")
+	timeFormat := timeFlags(flag.CommandLine)
 	flag.Usage = func() {
 		fs := flag.CommandLine
 		fcount := fs.Lookup("c")
+		fformat := fs.Lookup("format")
 
 		fmt.Printf("Usage: rid\n\n")
 		fmt.Printf("Options:\n")
 		fmt.Printf("  rid dgm3w9sh9f5flv5s\t\tDecode the supplied Base32 ID\n")
-		fmt.Printf("  rid -%s N\t\t\t%s default: %s\n\n", fcount.Name, fcount.Usage, fcount.DefValue)
+		fmt.Printf("  rid -\t\t\t\tDecode IDs read from stdin, one per line\n")
+		fmt.Printf("  rid -%s N\t\t\t%s default: %s\n", fcount.Name, fcount.Usage, fcount.DefValue)
+		fmt.Printf("  rid -%s FORMAT\t\t%s default: %s\n", fformat.Name, fformat.Usage, fformat.DefValue)
+		fmt.Printf("  rid -json\t\t\tShorthand for -format json\n")
+		fmt.Printf("  rid -t TIME\t\t\tGenerate IDs for TIME: RFC 3339, Unix seconds or\n")
+		fmt.Printf("\t\t\t\trelative to now like -2h; alias --time\n")
+		fmt.Printf("  rid -c N -from TIME -to TIME\tGenerate N IDs spread evenly over a time range\n")
+		fmt.Printf("  rid -utc | -tz Area/City\tWrite inspected times in UTC or a time zone;\n")
+		fmt.Printf("\t\t\t\tdefault: local time\n")
+		fmt.Printf("  rid -time-format LAYOUT\tWrite inspected times with a Go layout or one of\n")
+		fmt.Printf("\t\t\t\trfc3339, rfc3339nano, rfc1123, datetime, kitchen\n\n")
+		fmt.Printf("Commands:\n")
+		fmt.Printf("  rid analyze < FILE\t\tReport how k-sorted a stream of IDs is\n")
+		fmt.Printf("  rid convert -to FORM [ID...]\tConvert IDs between base32, hex, bytes, uuid and go\n")
+		fmt.Printf("  rid grep [-annotate] [FILE...]\tFind IDs in text such as logs, with their times\n")
+		fmt.Printf("  rid merge [-u] FILE...\tMerge files of IDs sorted by rid sort\n")
+		fmt.Printf("  rid resolve -f FILE PREFIX...\tResolve abbreviated IDs against a list of IDs\n")
+		fmt.Printf("  rid serve [-addr HOST:PORT]\tServe /new, /inspect/{id} and /health over HTTP\n")
+		fmt.Printf("  rid sort [-u] [FILE...]\tSort IDs into the ID order rid merge requires\n")
+		fmt.Printf("  rid stats [-by BUCKET] [FILE...]\tReport counts, duplicates, time range and peak rate\n")
+		fmt.Printf("  rid validate [FILE...]\tCheck every line is a valid ID, exiting 1 if not\n\n")
 		fmt.Printf("With no parameters, rid generates %s random ID encoded as Base32.\n", fcount.DefValue)
 		fmt.Printf("Generate and inspect 4 random IDs using Linux/Unix command substitution:\n")
 		fmt.Printf("  rid `rid -c 4`\n")
+		fmt.Printf("or, for any number of IDs, a pipe:\n")
+		fmt.Printf("  rid -c 1000000 | rid -\n")
 	}
 	flag.Parse()
 	args := flag.Args()
 
-	if count > 1 && len(args) > 0 {
+	if (count > 1 || at != "" || from != "" || to != "") && len(args) > 0 {
 		fmt.Fprintf(flag.CommandLine.Output(),
 			"rid: Error, cannot generate ID(s) and inspect at the same time.\n")
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	if *jsonOut {
+		format = "json"
+	}
+	tf, err := timeFormat()
+	if err != nil {
+		fmt.Fprintf(flag.CommandLine.Output(), "rid: %s\n", err)
+		os.Exit(2)
+	}
+	out, err := newOutput(os.Stdout, format, tf)
+	if err != nil {
+		fmt.Fprintf(flag.CommandLine.Output(), "rid: %s\n", err)
+		os.Exit(2)
+	}
+
+	timeAt, err := generationTimes(at, from, to, count)
+	if err != nil {
+		fmt.Fprintf(flag.CommandLine.Output(), "rid: %s\n", err)
+		os.Exit(2)
+	}
+
+	status := 0
 	if len(args) > 0 {
-		// attempt to decode each as an rid
+		// attempt to decode each as an rid; - reads IDs from stdin, one per line
 		for _, arg := range args {
+			if arg == "-" {
+				err := scanIDs(os.Stdin, func(line int, text string, id rid.ID, err error) bool {
+					if err != nil {
+						out.Invalid(line, text, err)
+						status = 1
+						return true
+					}
+					out.Inspected(id)
+					return true
+				})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+					status = 1
+				}
+				continue
+			}
 			id, err := rid.FromString(arg)
 			if err != nil {
-				fmt.Printf("[%s] %s\n", arg, err)
+				out.Invalid(0, arg, err)
+				status = 1
 				continue
 			}
-
-			fmt.Printf("%s ts:%d rnd:%15d %s ID{%s }\n", arg,
-				id.Timestamp(), id.Random(), id.Time(), asHex(id.Bytes()))
+			out.Inspected(id)
 		}
 	} else {
 		// generate one or -c N ids
-		for c := 1; c <= count; c++ {
-			fmt.Fprintf(os.Stdout, "%s\n", rid.New())
+		for c := 0; c < count; c++ {
+			out.Generated(rid.NewWithTime(timeAt(c)))
 		}
 	}
+
+	if err := out.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		status = 1
+	}
+	os.Exit(status)
 }
 
 func asHex(b []byte) string {
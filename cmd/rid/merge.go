@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"iter"
+	"os"
+
+	"github.com/mwyvr/rid"
+)
+
+// mergeCmd merges files of sorted IDs into a single sorted stream. Files are
+// read a line at a time, so inputs may be larger than memory.
+func mergeCmd(args []string) int {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	unique := fs.Bool("u", false, "Output each distinct ID only once")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid merge [-u] FILE...\n\n")
+		fmt.Fprintf(fs.Output(), "Merges files of IDs, each sorted in ascending ID order, to stdout; - reads stdin.\n")
+		fmt.Fprintf(fs.Output(), "ID order differs from the text order of sort(1); sort inputs with rid sort.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if len(fs.Args()) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var (
+		seqs   = make([]iter.Seq[rid.ID], 0, len(fs.Args()))
+		errs   = make([]error, len(fs.Args()))
+		failed bool // stop output at the first bad input
+	)
+	for i, name := range fs.Args() {
+		f, err := openInput(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+
+		seqs = append(seqs, func(yield func(rid.ID) bool) {
+			var prev rid.ID
+			err := scanIDs(f, func(line int, text string, id rid.ID, err error) bool {
+				switch {
+				case err != nil:
+					errs[i], failed = fmt.Errorf("%s:%d: [%s] %w", name, line, text, err), true
+					return false
+				case id.Compare(prev) < 0:
+					errs[i], failed = fmt.Errorf("%s:%d: [%s] not in ID order; sort inputs with rid sort", name, line, text), true
+					return false
+				}
+				prev = id
+				return yield(id)
+			})
+			if err != nil && errs[i] == nil {
+				errs[i], failed = err, true
+			}
+		})
+	}
+
+	merged := rid.Merge(seqs...)
+	if *unique {
+		merged = rid.MergeUnique(seqs...)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	for id := range merged {
+		if failed {
+			break
+		}
+		fmt.Fprintf(w, "%s\n", id)
+	}
+
+	status := 0
+	for _, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+			status = 1
+		}
+	}
+
+	return status
+}
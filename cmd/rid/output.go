@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// formats lists the values accepted by -format
+const formats = "text, csv, tsv, json or ndjson"
+
+// record is the structured form of an ID written by the csv, tsv, json and
+// ndjson output formats.
+type record struct {
+	ID        string `json:"id"`
+	Hex       string `json:"hex"`
+	Timestamp int64  `json:"timestamp"`
+	Time      string `json:"time"` // RFC 3339 unless -time-format is given
+	Random    uint64 `json:"random"`
+}
+
+func newRecord(id rid.ID, tf timeFormat) record {
+	return record{
+		ID:        id.String(),
+		Hex:       hex.EncodeToString(id.Bytes()),
+		Timestamp: id.Timestamp(),
+		Time:      tf.format(id, time.RFC3339),
+		Random:    id.Random(),
+	}
+}
+
+// output writes generated and inspected IDs, and IDs that failed to decode,
+// in one of the supported formats.
+type output interface {
+	Generated(id rid.ID)
+	Inspected(id rid.ID)
+	// Invalid reports text that failed to decode, read from line of stdin or,
+	// if line is 0, from the command line.
+	Invalid(line int, text string, err error)
+	// Close flushes buffered output, returning any write error.
+	Close() error
+}
+
+// newOutput returns an output writing format to w, with times written as
+// tf.
+func newOutput(w io.Writer, format string, tf timeFormat) (output, error) {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case "text":
+		return &textOutput{w: bw, tf: tf}, nil
+	case "json":
+		return &jsonOutput{w: bw, tf: tf, array: true}, nil
+	case "ndjson":
+		return &jsonOutput{w: bw, tf: tf}, nil
+	case "csv", "tsv":
+		cw := csv.NewWriter(bw)
+		if format == "tsv" {
+			cw.Comma = '\t'
+		}
+		return &csvOutput{w: bw, cw: cw, tf: tf}, nil
+	}
+
+	return nil, fmt.Errorf("unknown format %q, want %s", format, formats)
+}
+
+// invalid formats a decoding failure for Output.Invalid.
+func invalid(line int, text string, err error) string {
+	if line > 0 {
+		return fmt.Sprintf("line %d: [%s] %s", line, text, err)
+	}
+
+	return fmt.Sprintf("[%s] %s", text, err)
+}
+
+// textOutput writes generated IDs one per line and inspected IDs in the
+// original human-readable form.
+type textOutput struct {
+	w  *bufio.Writer
+	tf timeFormat
+}
+
+func (o *textOutput) Generated(id rid.ID) {
+	fmt.Fprintf(o.w, "%s\n", id)
+}
+
+func (o *textOutput) Inspected(id rid.ID) {
+	fmt.Fprintf(o.w, "%s ts:%d rnd:%15d %s ID{%s }\n", id,
+		id.Timestamp(), id.Random(), o.tf.format(id, ""), asHex(id.Bytes()))
+}
+
+func (o *textOutput) Invalid(line int, text string, err error) {
+	fmt.Fprintf(o.w, "%s\n", invalid(line, text, err))
+}
+
+func (o *textOutput) Close() error {
+	return o.w.Flush()
+}
+
+// jsonOutput writes records as a JSON array or, for ndjson, one JSON object
+// per line.
+type jsonOutput struct {
+	w     *bufio.Writer
+	tf    timeFormat
+	array bool
+	n     int
+}
+
+func (o *jsonOutput) Generated(id rid.ID) {
+	o.write(newRecord(id, o.tf))
+}
+
+func (o *jsonOutput) Inspected(id rid.ID) {
+	o.write(newRecord(id, o.tf))
+}
+
+func (o *jsonOutput) write(r record) {
+	b, _ := json.Marshal(r)
+	switch {
+	case !o.array:
+	case o.n == 0:
+		o.w.WriteString("[\n")
+	default:
+		o.w.WriteString(",\n")
+	}
+	o.w.Write(b)
+	if !o.array {
+		o.w.WriteByte('\n')
+	}
+	o.n++
+}
+
+func (o *jsonOutput) Invalid(line int, text string, err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", invalid(line, text, err))
+}
+
+func (o *jsonOutput) Close() error {
+	if o.array {
+		if o.n == 0 {
+			o.w.WriteString("[")
+		}
+		o.w.WriteString("\n]\n")
+	}
+
+	return o.w.Flush()
+}
+
+// csvOutput writes records as comma or tab separated values with a header.
+type csvOutput struct {
+	w      *bufio.Writer
+	cw     *csv.Writer
+	tf     timeFormat
+	header bool
+}
+
+func (o *csvOutput) Generated(id rid.ID) {
+	o.write(newRecord(id, o.tf))
+}
+
+func (o *csvOutput) Inspected(id rid.ID) {
+	o.write(newRecord(id, o.tf))
+}
+
+func (o *csvOutput) write(r record) {
+	if !o.header {
+		o.cw.Write([]string{"id", "hex", "timestamp", "time", "random"})
+		o.header = true
+	}
+	o.cw.Write([]string{r.ID, r.Hex, strconv.FormatInt(r.Timestamp, 10), r.Time, strconv.FormatUint(r.Random, 10)})
+}
+
+func (o *csvOutput) Invalid(line int, text string, err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", invalid(line, text, err))
+}
+
+func (o *csvOutput) Close() error {
+	o.cw.Flush()
+	if err := o.cw.Error(); err != nil {
+		return err
+	}
+
+	return o.w.Flush()
+}
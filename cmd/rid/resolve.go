@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mwyvr/rid"
+)
+
+// resolveCmd resolves abbreviated IDs, git style, against a file of IDs.
+func resolveCmd(args []string) int {
+	fs := flag.NewFlagSet("resolve", flag.ExitOnError)
+	file := fs.String("f", "", "File of IDs, one per line, to resolve against; - for stdin")
+	shortest := fs.Bool("s", false, "Print the shortest unambiguous prefix of each ID in the file")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid resolve -f FILE PREFIX...\n")
+		fmt.Fprintf(fs.Output(), "       rid resolve -f FILE -s\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *file == "" || (len(fs.Args()) == 0 && !*shortest) {
+		fs.Usage()
+		return 2
+	}
+
+	ids, err := readIDFile(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+	index := rid.NewPrefixIndex(ids...)
+
+	if *shortest {
+		for _, id := range ids {
+			fmt.Printf("%s %s\n", index.Shortest(id), id)
+		}
+		return 0
+	}
+
+	status := 0
+	for _, prefix := range fs.Args() {
+		id, err := index.Resolve(prefix)
+		var ambiguous *rid.AmbiguousPrefixError
+		switch {
+		case errors.As(err, &ambiguous):
+			fmt.Fprintf(os.Stderr, "[%s] ambiguous, candidates:\n", prefix)
+			for _, c := range ambiguous.Candidates {
+				fmt.Fprintf(os.Stderr, "  %s\n", c)
+			}
+			if more := ambiguous.Count - len(ambiguous.Candidates); more > 0 {
+				fmt.Fprintf(os.Stderr, "  ... and %d more\n", more)
+			}
+			status = 1
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "[%s] %s\n", prefix, err)
+			status = 1
+		default:
+			fmt.Printf("%s\n", id)
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// maxServeCount caps the number of IDs a single /new request may ask for
+const maxServeCount = 10000
+
+// serveCmd runs an HTTP service generating and inspecting IDs until
+// interrupted.
+func serveCmd(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid serve [-addr HOST:PORT]\n\n")
+		fmt.Fprintf(fs.Output(), "Endpoints:\n")
+		fmt.Fprintf(fs.Output(), "  GET /new?n=N\t\tGenerate N IDs, default 1, at most %d, one per line\n", maxServeCount)
+		fmt.Fprintf(fs.Output(), "  GET /inspect/{id}\tDecode an ID, as JSON\n")
+		fmt.Fprintf(fs.Output(), "  GET /health\t\tReport the service is up\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	srv := &http.Server{
+		Handler:           newServeMux(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		MaxHeaderBytes:    1 << 14,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// bind before announcing, so a failure is reported on its own
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+	fmt.Fprintf(os.Stderr, "rid: serving on %s\n", ln.Addr())
+
+	select {
+	case err := <-errc:
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	case <-ctx.Done():
+	}
+
+	// drain in-flight requests, giving up after a grace period
+	shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdown); err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+	if err := <-errc; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// newServeMux returns the handler for the endpoints of rid serve.
+func newServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /new", handleNew)
+	mux.HandleFunc("GET /inspect/{id}", handleInspect)
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintf(w, "ok\n")
+	})
+
+	return mux
+}
+
+// handleNew writes n generated IDs, one per line.
+func handleNew(w http.ResponseWriter, r *http.Request) {
+	n := 1
+	if s := r.URL.Query().Get("n"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 1 || v > maxServeCount {
+			http.Error(w, fmt.Sprintf("n must be from 1 to %d", maxServeCount), http.StatusBadRequest)
+			return
+		}
+		n = v
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(bw, "%s\n", rid.New())
+	}
+	bw.Flush()
+}
+
+// handleInspect writes the decoded form of the ID in the path as JSON.
+func handleInspect(w http.ResponseWriter, r *http.Request) {
+	id, err := rid.FromString(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newRecord(id, timeFormat{}))
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+func TestServeNew(t *testing.T) {
+	srv := httptest.NewServer(newServeMux())
+	defer srv.Close()
+
+	tests := []struct {
+		query  string
+		status int
+		count  int
+	}{
+		{"", http.StatusOK, 1},
+		{"?n=1", http.StatusOK, 1},
+		{"?n=25", http.StatusOK, 25},
+		{"?n=10000", http.StatusOK, maxServeCount},
+		{"?n=10001", http.StatusBadRequest, 0},
+		{"?n=0", http.StatusBadRequest, 0},
+		{"?n=-1", http.StatusBadRequest, 0},
+		{"?n=many", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		status, body := get(t, srv.URL+"/new"+tt.query)
+		if status != tt.status {
+			t.Errorf("GET /new%s status = %d, want %d", tt.query, status, tt.status)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		lines := strings.Fields(body)
+		if len(lines) != tt.count {
+			t.Errorf("GET /new%s returned %d IDs, want %d", tt.query, len(lines), tt.count)
+		}
+		for _, line := range lines {
+			if _, err := rid.FromString(line); err != nil {
+				t.Fatalf("GET /new%s returned %q: %v", tt.query, line, err)
+			}
+		}
+	}
+}
+
+func TestServeInspect(t *testing.T) {
+	srv := httptest.NewServer(newServeMux())
+	defer srv.Close()
+
+	status, body := get(t, srv.URL+"/inspect/dfp7emzzzzy30ey2")
+	if status != http.StatusOK {
+		t.Fatalf("GET /inspect status = %d, want %d", status, http.StatusOK)
+	}
+	var r record
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != "dfp7emzzzzy30ey2" || r.Hex != "63ac76d3fffffc3037c2" || r.Timestamp != 1672246995 || r.Random != 281474912761794 {
+		t.Errorf("GET /inspect = %+v", r)
+	}
+
+	for _, path := range []string{"/inspect/dfp7emzzzzy30eu2", "/inspect/dfp7em"} {
+		if status, _ := get(t, srv.URL+path); status != http.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want %d", path, status, http.StatusBadRequest)
+		}
+	}
+	if status, _ := get(t, srv.URL+"/inspect/"); status != http.StatusNotFound {
+		t.Errorf("GET /inspect/ status = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestServeHealth(t *testing.T) {
+	srv := httptest.NewServer(newServeMux())
+	defer srv.Close()
+
+	if status, body := get(t, srv.URL+"/health"); status != http.StatusOK || body != "ok\n" {
+		t.Errorf("GET /health = %d %q, want %d ok", status, body, http.StatusOK)
+	}
+	resp, err := http.Post(srv.URL+"/health", "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST /health status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+// get returns the status and body of a GET request for url.
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var b strings.Builder
+	if _, err := io.Copy(&b, resp.Body); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, b.String()
+}
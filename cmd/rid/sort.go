@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mwyvr/rid"
+)
+
+// sortCmd sorts IDs into ascending ID order, the order rid merge requires.
+// The Base32 character set places k before j, so sort(1) order differs.
+func sortCmd(args []string) int {
+	fs := flag.NewFlagSet("sort", flag.ExitOnError)
+	unique := fs.Bool("u", false, "Output each distinct ID only once")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid sort [-u] [FILE...]\n\n")
+		fmt.Fprintf(fs.Output(), "Sorts IDs read from the files or stdin into ascending ID order, which\n")
+		fmt.Fprintf(fs.Output(), "differs from the text order of sort(1).\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var ids []rid.ID
+	for _, name := range files {
+		read, err := readIDFile(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+			return 1
+		}
+		ids = append(ids, read...)
+	}
+	rid.SortFast(ids)
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	for i, id := range ids {
+		if *unique && i > 0 && id == ids[i-1] {
+			continue
+		}
+		fmt.Fprintf(w, "%s\n", id)
+	}
+
+	return 0
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// randomSpace is the number of distinct random values an ID in a given second
+// may take: 48 bits
+const randomSpace = 1 << 48
+
+// buckets maps -by values to their histogram bucket width in seconds
+var buckets = map[string]int64{
+	"second": 1,
+	"minute": 60,
+	"hour":   3600,
+}
+
+// statsCmd reports on a corpus of IDs: counts, duplicates, time range, a
+// histogram of IDs over time and the collision risk at the peak rate.
+func statsCmd(args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	by := fs.String("by", "hour", "Histogram bucket: second, minute or hour")
+	timeFormat := timeFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid stats [-by second|minute|hour] [FILE...]\n\n")
+		fmt.Fprintf(fs.Output(), "Reads IDs, one per line, from the files or stdin and reports on them.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	tf, err := timeFormat()
+	if err != nil {
+		fmt.Fprintf(fs.Output(), "rid: %s\n", err)
+		return 2
+	}
+
+	step, ok := buckets[*by]
+	if !ok {
+		fmt.Fprintf(fs.Output(), "rid: unknown bucket %q, want second, minute or hour\n", *by)
+		return 2
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := 0
+	var ids []rid.ID
+	for _, name := range files {
+		f, err := openInput(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+			status = 1
+			continue
+		}
+		err = scanIDs(f, func(line int, text string, id rid.ID, err error) bool {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s:%d: [%s] %s\n", name, line, text, err)
+				status = 1
+				return true
+			}
+			ids = append(ids, id)
+			return true
+		})
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s: %s\n", name, err)
+			status = 1
+		}
+	}
+
+	fmt.Printf("IDs:        %d\n", len(ids))
+	if len(ids) == 0 {
+		return status
+	}
+
+	sum := summarize(ids, step)
+	fmt.Printf("Duplicates: %d\n", sum.duplicates)
+	fmt.Printf("First:      %s\n", tf.formatTime(sum.first, time.RFC3339))
+	fmt.Printf("Last:       %s\n", tf.formatTime(sum.last, time.RFC3339))
+	fmt.Printf("Span:       %s\n", sum.last.Sub(sum.first))
+	fmt.Printf("Peak:       %d IDs/s at %s\n", sum.peak, tf.formatTime(sum.peakAt, time.RFC3339))
+	fmt.Printf("P(collide): %.3g within the peak second\n", collisionProbability(sum.peak))
+
+	fmt.Printf("\nIDs by %s:\n", *by)
+	for _, b := range sum.histogram {
+		fmt.Printf("  %s %d\n", tf.formatTime(b.start, time.RFC3339), b.count)
+	}
+
+	return status
+}
+
+// summary describes a corpus of IDs for rid stats.
+type summary struct {
+	count       int
+	duplicates  int       // IDs repeating an earlier ID
+	first, last time.Time // the earliest and latest ID times
+	peak        int       // the most IDs sharing one second
+	peakAt      time.Time // the first second holding peak IDs
+	histogram   []bucket  // ascending, omitting empty buckets
+}
+
+// bucket counts the IDs of a histogram interval.
+type bucket struct {
+	start time.Time
+	count int
+}
+
+// summarize returns a summary of ids, which must not be empty, with a
+// histogram of step second intervals. ids is sorted in place.
+func summarize(ids []rid.ID, step int64) summary {
+	// sorted, duplicates are adjacent and IDs of one second form a run
+	rid.SortFast(ids)
+	sum := summary{count: len(ids), first: ids[0].Time(), last: ids[len(ids)-1].Time()}
+	run := 0
+	for i, id := range ids {
+		if i > 0 && id == ids[i-1] {
+			sum.duplicates++
+		}
+		if i > 0 && id.Timestamp() == ids[i-1].Timestamp() {
+			run++
+		} else {
+			run = 1
+		}
+		if run > sum.peak {
+			sum.peak, sum.peakAt = run, id.Time()
+		}
+
+		start := id.Timestamp() / step * step
+		if n := len(sum.histogram); n > 0 && sum.histogram[n-1].start.Unix() == start {
+			sum.histogram[n-1].count++
+		} else {
+			sum.histogram = append(sum.histogram, bucket{start: time.Unix(start, 0), count: 1})
+		}
+	}
+
+	return sum
+}
+
+// collisionProbability estimates, by the birthday approximation, the chance
+// that any two of n IDs generated within the same second share a random
+// value.
+func collisionProbability(n int) float64 {
+	x := float64(n)
+	return -math.Expm1(-x * (x - 1) / (2 * randomSpace))
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+func TestSummarize(t *testing.T) {
+	t0 := time.Unix(1672531200, 0) // 2023-01-01T00:00:00Z
+	at := func(d time.Duration) rid.ID { return rid.NewWithTime(t0.Add(d)) }
+	dup := at(0)
+	corpus := []rid.ID{
+		dup, dup, dup, at(0), // 2 duplicates, 4 IDs in the first second
+		at(time.Minute), at(time.Minute), at(time.Minute), at(time.Minute), at(time.Minute), // the peak second
+		at(time.Minute + time.Second),
+		at(2 * time.Hour), at(2 * time.Hour), // 2 hours on, skipping an hour
+	}
+
+	tests := []struct {
+		step      int64
+		histogram []bucket
+	}{
+		{1, []bucket{{t0, 4}, {t0.Add(time.Minute), 5}, {t0.Add(time.Minute + time.Second), 1}, {t0.Add(2 * time.Hour), 2}}},
+		{60, []bucket{{t0, 4}, {t0.Add(time.Minute), 6}, {t0.Add(2 * time.Hour), 2}}},
+		{3600, []bucket{{t0, 10}, {t0.Add(2 * time.Hour), 2}}},
+	}
+	for _, tt := range tests {
+		ids := append([]rid.ID(nil), corpus...)
+		rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+		want := summary{
+			count:      len(corpus),
+			duplicates: 2,
+			first:      t0,
+			last:       t0.Add(2 * time.Hour),
+			peak:       5,
+			peakAt:     t0.Add(time.Minute),
+			histogram:  tt.histogram,
+		}
+		if got := summarize(ids, tt.step); !reflect.DeepEqual(got, want) {
+			t.Errorf("summarize(step %d) = %+v, want %+v", tt.step, got, want)
+		}
+	}
+
+	// with a tie, the peak is the earlier second
+	ids := []rid.ID{at(time.Hour), at(time.Hour), at(0), at(0)}
+	if got := summarize(ids, 1); got.peak != 2 || !got.peakAt.Equal(t0) {
+		t.Errorf("summarize() peak = %d at %v, want 2 at %v", got.peak, got.peakAt, t0)
+	}
+}
+
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		n    int
+		want float64
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 1.0 / (1 << 48)},
+		{1000, 999000.0 / (1 << 49)},
+		{1 << 24, 0.3935}, // 1 - e^-0.5, the birthday bound at the square root
+	}
+	for _, tt := range tests {
+		got := collisionProbability(tt.n)
+		if tt.want == 0 {
+			if got != 0 {
+				t.Errorf("collisionProbability(%d) = %g, want 0", tt.n, got)
+			}
+			continue
+		}
+		if math.Abs(got-tt.want)/tt.want > 1e-3 {
+			t.Errorf("collisionProbability(%d) = %g, want %g", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// maxTimestamp is the largest timestamp an ID can hold: 4 bytes of seconds
+const maxTimestamp = 1<<32 - 1
+
+// parseTime parses s as an RFC 3339 time, Unix seconds, or a duration
+// relative to now such as -2h or 30m.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	var t time.Time
+	if v, err := time.Parse(time.RFC3339, s); err == nil {
+		t = v
+	} else if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t = time.Unix(n, 0)
+	} else if d, err := time.ParseDuration(s); err == nil {
+		t = now.Add(d)
+	} else {
+		return t, fmt.Errorf("invalid time %q: want RFC 3339, Unix seconds or a duration relative to now like -2h", s)
+	}
+
+	if t.Unix() < 0 || t.Unix() > maxTimestamp {
+		return t, fmt.Errorf("time %q outside the range of ID timestamps, 1970 to 2106", s)
+	}
+
+	return t, nil
+}
+
+// generationTimes returns a function giving the time of the i-th of count
+// generated IDs: the time at, times spread evenly from from to to, or if
+// none are given the current time.
+func generationTimes(at, from, to string, count int) (func(i int) time.Time, error) {
+	now := time.Now()
+	switch {
+	case at != "" && (from != "" || to != ""):
+		return nil, errors.New("-t cannot be combined with -from and -to")
+	case at != "":
+		t, err := parseTime(at, now)
+		return func(int) time.Time { return t }, err
+	case from == "" && to == "":
+		return func(int) time.Time { return time.Now() }, nil
+	case from == "" || to == "":
+		return nil, errors.New("-from and -to must be given together")
+	}
+
+	t1, err := parseTime(from, now)
+	if err != nil {
+		return nil, err
+	}
+	t2, err := parseTime(to, now)
+	if err != nil {
+		return nil, err
+	}
+	if t2.Before(t1) {
+		return nil, errors.New("-to is before -from")
+	}
+
+	span := float64(t2.Sub(t1))
+	return func(i int) time.Time {
+		if count < 2 {
+			return t1
+		}
+		return t1.Add(time.Duration(span * float64(i) / float64(count-1)))
+	}, nil
+}
+
+// timeLayouts maps the -time-format aliases to layouts
+var timeLayouts = map[string]string{
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"rfc1123":     time.RFC1123Z,
+	"datetime":    time.DateTime,
+	"kitchen":     time.Kitchen,
+}
+
+// timeFormat controls how ID times are written: in a location, nil for local
+// time, and with a layout, empty for the default of each output format.
+type timeFormat struct {
+	loc    *time.Location
+	layout string
+}
+
+// timeFlags defines the -utc, -tz and -time-format flags on fs, returning a
+// function giving the timeFormat they select once fs is parsed.
+func timeFlags(fs *flag.FlagSet) func() (timeFormat, error) {
+	utc := fs.Bool("utc", false, "Write times in UTC")
+	tz := fs.String("tz", "", "Write times in the IANA time zone `Area/City`")
+	layout := fs.String("time-format", "", "Write times with a Go layout or one of: rfc3339, rfc3339nano, rfc1123, datetime, kitchen")
+
+	return func() (timeFormat, error) {
+		return newTimeFormat(*utc, *tz, *layout)
+	}
+}
+
+// newTimeFormat returns the timeFormat selected by the -utc, -tz and
+// -time-format flags. layout is an alias from timeLayouts or a Go layout.
+func newTimeFormat(utc bool, tz, layout string) (timeFormat, error) {
+	f := timeFormat{layout: layout}
+	if l, ok := timeLayouts[strings.ToLower(layout)]; ok {
+		f.layout = l
+	}
+
+	switch {
+	case utc && tz != "":
+		return f, errors.New("-utc cannot be combined with -tz")
+	case utc:
+		f.loc = time.UTC
+	case tz != "":
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return f, fmt.Errorf("invalid time zone %q: want an IANA name like America/Vancouver", tz)
+		}
+		f.loc = loc
+	}
+
+	return f, nil
+}
+
+// format returns the time of id, using layout def unless a layout was chosen;
+// an empty def uses the time.Time String form.
+func (f timeFormat) format(id rid.ID, def string) string {
+	t := id.Time()
+	if f.loc != nil {
+		t = id.TimeIn(f.loc)
+	}
+
+	return f.formatTime(t, def)
+}
+
+// formatTime returns t as format does the time of an ID.
+func (f timeFormat) formatTime(t time.Time, def string) string {
+	if f.loc != nil {
+		t = t.In(f.loc)
+	}
+
+	layout := cmp.Or(f.layout, def)
+	if layout == "" {
+		return t.String()
+	}
+
+	return t.Format(layout)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Unix(1672246995, 0)
+	tests := []struct {
+		s    string
+		want time.Time
+		err  bool
+	}{
+		{"2023-01-01T00:00:00Z", time.Unix(1672531200, 0), false},
+		{"2023-01-01T00:00:00-08:00", time.Unix(1672560000, 0), false},
+		{"1672246995", now, false},
+		{"0", time.Unix(0, 0), false},
+		{"4294967295", time.Unix(maxTimestamp, 0), false},
+		{"-2h", now.Add(-2 * time.Hour), false},
+		{"30m", now.Add(30 * time.Minute), false},
+		{"4294967296", time.Time{}, true},           // after 2106
+		{"-1", time.Time{}, true},                   // before 1970
+		{"1969-12-31T23:59:59Z", time.Time{}, true}, // before 1970
+		{"yesterday", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseTime(tt.s, now)
+		if tt.err {
+			if err == nil {
+				t.Errorf("parseTime(%q) = %v, want error", tt.s, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseTime(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestGenerationTimes(t *testing.T) {
+	t1, t2 := time.Unix(1672531200, 0), time.Unix(1672531200+90, 0)
+	tests := []struct {
+		name         string
+		at, from, to string
+		count        int
+		want         []time.Time
+	}{
+		{"at", "1672531200", "", "", 3, []time.Time{t1, t1, t1}},
+		{"range", "", "1672531200", "1672531290", 4, []time.Time{t1, t1.Add(30 * time.Second), t1.Add(60 * time.Second), t2}},
+		{"range of one", "", "1672531200", "1672531290", 1, []time.Time{t1}},
+		{"empty range", "", "1672531200", "1672531200", 2, []time.Time{t1, t1}},
+	}
+	for _, tt := range tests {
+		timeAt, err := generationTimes(tt.at, tt.from, tt.to, tt.count)
+		if err != nil {
+			t.Errorf("%s: generationTimes() err=%v", tt.name, err)
+			continue
+		}
+		for i, want := range tt.want {
+			if got := timeAt(i); !got.Equal(want) {
+				t.Errorf("%s: time %d = %v, want %v", tt.name, i, got, want)
+			}
+		}
+	}
+
+	// with no times given, IDs are generated now
+	timeAt, err := generationTimes("", "", "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(timeAt(0)); d < 0 || d > time.Minute {
+		t.Errorf("default time %v is not now", timeAt(0))
+	}
+
+	errs := []struct {
+		name         string
+		at, from, to string
+	}{
+		{"at and range", "0", "0", "1"},
+		{"from only", "", "0", ""},
+		{"to only", "", "", "1"},
+		{"reversed", "", "1", "0"},
+		{"invalid at", "soon", "", ""},
+		{"invalid from", "", "soon", "1"},
+		{"invalid to", "", "0", "later"},
+	}
+	for _, tt := range errs {
+		if _, err := generationTimes(tt.at, tt.from, tt.to, 2); err == nil {
+			t.Errorf("%s: generationTimes() err=nil, want error", tt.name)
+		}
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	id, err := rid.FromString("dfp7emzzzzy30ey2") // 2022-12-28T17:03:15Z
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		utc    bool
+		tz     string
+		layout string
+		def    string
+		want   string
+	}{
+		{true, "", "", time.RFC3339, "2022-12-28T17:03:15Z"},
+		{true, "", "", "", "2022-12-28 17:03:15 +0000 UTC"},
+		{true, "", "datetime", time.RFC3339, "2022-12-28 17:03:15"},
+		{true, "", "Kitchen", "", "5:03PM"},
+		{false, "Asia/Tokyo", "", time.RFC3339, "2022-12-29T02:03:15+09:00"},
+		{false, "America/Vancouver", "2006-01-02 15:04 MST", "", "2022-12-28 09:03 PST"},
+	}
+	for _, tt := range tests {
+		tf, err := newTimeFormat(tt.utc, tt.tz, tt.layout)
+		if err != nil {
+			t.Errorf("newTimeFormat(%v, %q, %q) err=%v", tt.utc, tt.tz, tt.layout, err)
+			continue
+		}
+		if got := tf.format(id, tt.def); got != tt.want {
+			t.Errorf("newTimeFormat(%v, %q, %q).format() = %q, want %q", tt.utc, tt.tz, tt.layout, got, tt.want)
+		}
+	}
+
+	if _, err := newTimeFormat(true, "Asia/Tokyo", ""); err == nil {
+		t.Error("newTimeFormat() with -utc and -tz err=nil, want error")
+	}
+	if _, err := newTimeFormat(false, "Nowhere/City", ""); err == nil {
+		t.Error("newTimeFormat() with unknown zone err=nil, want error")
+	}
+}
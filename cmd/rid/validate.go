@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// validateCmd checks that every line of its input files is a valid ID,
+// optionally within a window of time, printing each failure.
+func validateCmd(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	noFuture := fs.Bool("no-future", false, "Reject IDs with a timestamp in the future")
+	notBefore := fs.String("not-before", "", "Reject IDs with a timestamp before TIME: RFC 3339, Unix seconds or relative to now like -24h")
+	quiet := fs.Bool("q", false, "Print nothing; report only through the exit status")
+	timeFormat := timeFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid validate [-no-future] [-not-before TIME] [FILE...]\n\n")
+		fmt.Fprintf(fs.Output(), "Checks each non-blank line of the files, or stdin, is a valid ID, printing\n")
+		fmt.Fprintf(fs.Output(), "failures as FILE:LINE: and exiting 1 if there are any.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	tf, err := timeFormat()
+	if err != nil {
+		fmt.Fprintf(fs.Output(), "rid: %s\n", err)
+		return 2
+	}
+
+	win := window{noFuture: *noFuture, now: time.Now(), tf: tf}
+	if *notBefore != "" {
+		t, err := parseTime(*notBefore, win.now)
+		if err != nil {
+			fmt.Fprintf(fs.Output(), "rid: %s\n", err)
+			return 2
+		}
+		win.notBefore = t
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	if *quiet {
+		w.Reset(io.Discard)
+	}
+	status := 0
+	for _, name := range files {
+		f, err := openInput(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+			status = 1
+			continue
+		}
+		failed, err := validate(w, f, name, win)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rid: %s: %s\n", name, err)
+			status = 1
+		}
+		if failed {
+			status = 1
+		}
+	}
+
+	return status
+}
+
+// window is the span of time IDs must fall within to pass rid validate.
+type window struct {
+	noFuture  bool // reject IDs after now
+	now       time.Time
+	notBefore time.Time // if not zero, reject IDs before this time
+	tf        timeFormat
+}
+
+// check returns an error describing why id falls outside the window, or nil.
+func (win window) check(id rid.ID) error {
+	t := id.Time()
+	switch {
+	case win.noFuture && t.After(win.now):
+		return fmt.Errorf("time %s is in the future", win.tf.format(id, time.RFC3339))
+	case !win.notBefore.IsZero() && t.Before(win.notBefore):
+		return fmt.Errorf("time %s is before %s", win.tf.format(id, time.RFC3339), win.tf.formatTime(win.notBefore, time.RFC3339))
+	}
+
+	return nil
+}
+
+// validate checks each non-blank line of r, read from the named input, is an
+// ID within win, writing failures to w as name:line:. It returns true if any
+// line failed.
+func validate(w io.Writer, r io.Reader, name string, win window) (bool, error) {
+	failed := false
+	err := scanIDs(r, func(line int, text string, id rid.ID, err error) bool {
+		if err == nil {
+			err = win.check(id)
+		}
+		if err != nil {
+			fmt.Fprintf(w, "%s:%d: [%s] %s\n", name, line, text, err)
+			failed = true
+		}
+		return true
+	})
+
+	return failed, err
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+func TestWindowCheck(t *testing.T) {
+	now := time.Unix(1672531200, 0) // 2023-01-01T00:00:00Z
+	utc := timeFormat{loc: time.UTC}
+	at := func(d time.Duration) rid.ID { return rid.NewWithTime(now.Add(d)) }
+	tests := []struct {
+		name string
+		win  window
+		id   rid.ID
+		err  string
+	}{
+		{"no window", window{now: now, tf: utc}, at(time.Hour), ""},
+		{"now", window{noFuture: true, now: now, tf: utc}, at(0), ""},
+		{"past", window{noFuture: true, now: now, tf: utc}, at(-time.Hour), ""},
+		{"future", window{noFuture: true, now: now, tf: utc}, at(time.Second),
+			"time 2023-01-01T00:00:01Z is in the future"},
+		{"future allowed", window{now: now, notBefore: now, tf: utc}, at(time.Hour), ""},
+		{"not before", window{now: now, notBefore: now, tf: utc}, at(0), ""},
+		{"before", window{now: now, notBefore: now, tf: utc}, at(-time.Second),
+			"time 2022-12-31T23:59:59Z is before 2023-01-01T00:00:00Z"},
+		{"within both", window{noFuture: true, now: now, notBefore: now.Add(-time.Hour), tf: utc}, at(-time.Minute), ""},
+		{"zone", window{noFuture: true, now: now, tf: timeFormat{loc: time.FixedZone("", 9*60*60)}}, at(time.Second),
+			"time 2023-01-01T09:00:01+09:00 is in the future"},
+	}
+	for _, tt := range tests {
+		err := tt.win.check(tt.id)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: check() err=%v, want nil", tt.name, err)
+		case tt.err != "" && (err == nil || err.Error() != tt.err):
+			t.Errorf("%s: check() err=%v, want %s", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1672531200, 0)
+	win := window{noFuture: true, now: now, notBefore: now.Add(-24 * time.Hour), tf: timeFormat{loc: time.UTC}}
+	past := rid.NewWithTime(now.Add(-time.Hour)).String()
+	future := rid.NewWithTime(now.Add(time.Hour)).String()
+	old := rid.NewWithTime(now.Add(-48 * time.Hour)).String()
+
+	tests := []struct {
+		name   string
+		input  string
+		failed bool
+		output string
+	}{
+		{"empty", "", false, ""},
+		{"valid", past + "\n\n  " + past + "  \n", false, ""},
+		{"invalid", past + "\nnot-an-id\n", true, "ids.txt:2: [not-an-id] rid: invalid id\n"},
+		{"future", "\n" + future, true, "ids.txt:2: [" + future + "] time 2023-01-01T01:00:00Z is in the future\n"},
+		{"old", old + "\n" + past + "\n", true, "ids.txt:1: [" + old + "] time 2022-12-30T00:00:00Z is before 2022-12-31T00:00:00Z\n"},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		failed, err := validate(&out, strings.NewReader(tt.input), "ids.txt", win)
+		if err != nil {
+			t.Errorf("%s: validate() err=%v", tt.name, err)
+		}
+		if failed != tt.failed || out.String() != tt.output {
+			t.Errorf("%s: validate() = %v, %q, want %v, %q", tt.name, failed, out.String(), tt.failed, tt.output)
+		}
+	}
+}
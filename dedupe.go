@@ -0,0 +1,112 @@
+package rid
+
+import "time"
+
+// DedupResult reports the outcome of Deduper.Add.
+type DedupResult int
+
+const (
+	// DedupUnique IDs have not been seen within the window.
+	DedupUnique DedupResult = iota
+	// DedupDuplicate IDs have been seen within the window.
+	DedupDuplicate
+	// DedupLate IDs are older than the window and cannot be checked.
+	DedupLate
+)
+
+func (r DedupResult) String() string {
+	switch r {
+	case DedupUnique:
+		return "unique"
+	case DedupDuplicate:
+		return "duplicate"
+	case DedupLate:
+		return "late"
+	default:
+		return "unknown"
+	}
+}
+
+// DedupStats counts the results returned by Deduper.Add.
+type DedupStats struct {
+	Unique     int
+	Duplicates int
+	Late       int
+}
+
+// Deduper removes duplicates from a stream of IDs using memory bounded by a
+// time window rather than the length of the stream.
+//
+// As IDs are k-sorted by second, a stream arrives in near timestamp order.
+// The Deduper tracks the newest timestamp seen and remembers only IDs whose
+// timestamp is within the window of it; older IDs are evicted. An ID older
+// than the window is reported as DedupLate, as it can no longer be checked.
+//
+// A Deduper is not safe for concurrent use.
+type Deduper struct {
+	window int64                     // seconds
+	newest int64                     // newest timestamp seen
+	seen   map[int64]map[ID]struct{} // IDs by timestamp
+	count  int                       // IDs in seen
+	stats  DedupStats
+}
+
+// NewDeduper returns a Deduper remembering IDs for window, truncated to whole
+// seconds with a minimum of one second.
+func NewDeduper(window time.Duration) *Deduper {
+	return &Deduper{
+		window: max(1, int64(window/time.Second)),
+		newest: -1,
+		seen:   make(map[int64]map[ID]struct{}),
+	}
+}
+
+// Add records id, returning DedupUnique the first time id is seen within the
+// window, DedupDuplicate for repeats and DedupLate if id is too old to check.
+func (d *Deduper) Add(id ID) DedupResult {
+	ts := id.Timestamp()
+	if ts > d.newest {
+		d.newest = ts
+		d.evict()
+	}
+	if ts < d.newest-d.window {
+		d.stats.Late++
+		return DedupLate
+	}
+
+	bucket := d.seen[ts]
+	if bucket == nil {
+		bucket = make(map[ID]struct{})
+		d.seen[ts] = bucket
+	}
+	if _, ok := bucket[id]; ok {
+		d.stats.Duplicates++
+		return DedupDuplicate
+	}
+	bucket[id] = struct{}{}
+	d.count++
+	d.stats.Unique++
+
+	return DedupUnique
+}
+
+// evict forgets IDs that have fallen out of the window.
+func (d *Deduper) evict() {
+	cutoff := d.newest - d.window
+	for ts, bucket := range d.seen {
+		if ts < cutoff {
+			d.count -= len(bucket)
+			delete(d.seen, ts)
+		}
+	}
+}
+
+// Len returns the number of IDs currently remembered.
+func (d *Deduper) Len() int {
+	return d.count
+}
+
+// Stats returns counts of the results returned by Add.
+func (d *Deduper) Stats() DedupStats {
+	return d.stats
+}
@@ -0,0 +1,64 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeduper(t *testing.T) {
+	base := time.Unix(1672246995, 0)
+	at := func(sec int) ID { return NewWithTime(base.Add(time.Duration(sec) * time.Second)) }
+
+	d := NewDeduper(10 * time.Second)
+	a, b, c := at(0), at(5), at(12)
+	steps := []struct {
+		id   ID
+		want DedupResult
+	}{
+		{a, DedupUnique},
+		{a, DedupDuplicate},
+		{b, DedupUnique},
+		{at(-3), DedupUnique}, // out of order, within window
+		{c, DedupUnique},      // evicts a
+		{b, DedupDuplicate},
+		{a, DedupLate},
+		{at(1), DedupLate},
+		{c, DedupDuplicate},
+	}
+	for i, s := range steps {
+		if got := d.Add(s.id); got != s.want {
+			t.Errorf("step %d: Add(%v) = %v, want %v", i, s.id, got, s.want)
+		}
+	}
+	if got, want := d.Stats(), (DedupStats{Unique: 4, Duplicates: 3, Late: 2}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	// a and at(-3) evicted
+	if got := d.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestDeduperBoundedMemory(t *testing.T) {
+	d := NewDeduper(time.Second)
+	start := time.Now()
+	for s := 0; s < 100; s++ {
+		for i := 0; i < 100; i++ {
+			if got := d.Add(NewWithTime(start.Add(time.Duration(s) * time.Second))); got != DedupUnique {
+				t.Fatalf("Add() = %v, want %v", got, DedupUnique)
+			}
+		}
+		// only the current and previous second are retained
+		if d.Len() > 200 {
+			t.Fatalf("Len() = %d, want <= 200", d.Len())
+		}
+	}
+}
+
+func TestDedupResultString(t *testing.T) {
+	for r, want := range map[DedupResult]string{DedupUnique: "unique", DedupDuplicate: "duplicate", DedupLate: "late", 99: "unknown"} {
+		if got := r.String(); got != want {
+			t.Errorf("String() = %s, want %s", got, want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package rid
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// filterHeaderLen is the length of the encoded Filter header: probes (4
+// bytes), bits (8 bytes) and count (8 bytes)
+const filterHeaderLen = 20
+
+// maxFilterProbes bounds the probes per ID, enough for a false-positive rate
+// far below any practical need
+const maxFilterProbes = 64
+
+var (
+	// ErrInvalidFilter is returned when decoding a malformed Filter encoding.
+	ErrInvalidFilter = errors.New("rid: invalid filter encoding")
+)
+
+// Filter is a Bloom filter specialised for IDs, answering "have we seen this
+// ID?" in constant space with no false negatives and a configurable rate of
+// false positives.
+//
+// The 6-byte random component of an ID is already high-entropy, so it is used
+// directly as hash material rather than hashing the ID.
+//
+// A Filter is not safe for concurrent use.
+type Filter struct {
+	words []uint64
+	m     uint64 // number of bits
+	k     uint32 // number of probes per ID
+	n     uint64 // number of IDs added
+}
+
+// NewFilter returns a Filter sized to hold n IDs with a false-positive rate
+// of p, e.g. 0.01 for 1%. Adding more than n IDs raises the false-positive
+// rate beyond p.
+func NewFilter(n int, p float64) *Filter {
+	if n < 1 {
+		n = 1
+	}
+	if !(p > 0 && p < 1) {
+		p = 0.01
+	}
+
+	// optimal bits and probes for n items at rate p
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max(64, (m+63)/64*64)
+	k := uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = min(max(1, k), maxFilterProbes)
+
+	return &Filter{words: make([]uint64, m/64), m: m, k: k}
+}
+
+// hashes returns two hash values for id using Kirsch-Mitzenmacher double
+// hashing; the random component supplies the entropy, the low bits of the
+// timestamp distinguish IDs sharing a random value across seconds.
+func hashes(id ID) (h1, h2 uint64) {
+	h1 = id.Random() | uint64(id[2])<<56 | uint64(id[3])<<48
+	h2 = bits.RotateLeft64(h1, 32) | 1
+
+	return h1, h2
+}
+
+// Add adds id to f.
+func (f *Filter) Add(id ID) {
+	h1, h2 := hashes(id)
+	for i := uint32(0); i < f.k; i++ {
+		b := h1 % f.m
+		f.words[b/64] |= 1 << (b % 64)
+		h1 += h2
+	}
+	f.n++
+}
+
+// Has returns true if id may have been added to f, and false if it definitely
+// has not.
+func (f *Filter) Has(id ID) bool {
+	h1, h2 := hashes(id)
+	for i := uint32(0); i < f.k; i++ {
+		b := h1 % f.m
+		if f.words[b/64]&(1<<(b%64)) == 0 {
+			return false
+		}
+		h1 += h2
+	}
+
+	return true
+}
+
+// Count returns the number of times Add has been called.
+func (f *Filter) Count() int {
+	return int(f.n)
+}
+
+// FalsePositiveRate returns the estimated false-positive rate of f given the
+// number of IDs added so far.
+func (f *Filter) FalsePositiveRate() float64 {
+	return math.Pow(1-math.Exp(-float64(f.k)*float64(f.n)/float64(f.m)), float64(f.k))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, filterHeaderLen+len(f.words)*8)
+	b = binary.BigEndian.AppendUint32(b, f.k)
+	b = binary.BigEndian.AppendUint64(b, f.m)
+	b = binary.BigEndian.AppendUint64(b, f.n)
+	for _, w := range f.words {
+		b = binary.BigEndian.AppendUint64(b, w)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the
+// contents of f.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < filterHeaderLen {
+		return ErrInvalidFilter
+	}
+
+	k := binary.BigEndian.Uint32(data)
+	m := binary.BigEndian.Uint64(data[4:])
+	n := binary.BigEndian.Uint64(data[12:])
+	data = data[filterHeaderLen:]
+	if k == 0 || k > maxFilterProbes || m == 0 || m%64 != 0 || uint64(len(data)) != m/8 {
+		return ErrInvalidFilter
+	}
+
+	words := make([]uint64, m/64)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(data[i*8:])
+	}
+	*f = Filter{words: words, m: m, k: k, n: n}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package rid
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	const n, p = 100_000, 0.01
+	f := NewFilter(n, p)
+	ids := randomIDs(n, 60)
+	for _, id := range ids {
+		f.Add(id)
+	}
+	for _, id := range ids {
+		if !f.Has(id) {
+			t.Fatalf("Has(%v) = false for added ID", id)
+		}
+	}
+	if f.Count() != n {
+		t.Errorf("Count() = %d, want %d", f.Count(), n)
+	}
+
+	fp := 0
+	trials := 100_000
+	for _, id := range randomIDs(trials, 60) {
+		if f.Has(id) {
+			fp++
+		}
+	}
+	// allow generous headroom over the configured rate
+	if rate := float64(fp) / float64(trials); rate > 2*p {
+		t.Errorf("false-positive rate %.4f, want about %.4f", rate, p)
+	}
+	if est := f.FalsePositiveRate(); est > 2*p || est < p/2 {
+		t.Errorf("FalsePositiveRate() = %.4f, want about %.4f", est, p)
+	}
+}
+
+func TestFilterSameRandom(t *testing.T) {
+	// IDs sharing a random component in different seconds are distinguished
+	f := NewFilter(1000, 0.001)
+	f.Add(IDs[4].id)
+	if f.Has(IDs[2].id) {
+		t.Errorf("Has(%v) = true, added only %v", IDs[2].id, IDs[4].id)
+	}
+}
+
+func TestFilterDefaults(t *testing.T) {
+	f := NewFilter(0, 0)
+	f.Add(IDs[0].id)
+	if !f.Has(IDs[0].id) {
+		t.Error("Has() = false for added ID")
+	}
+}
+
+func TestFilterBinary(t *testing.T) {
+	f := NewFilter(1000, 0.01)
+	ids := randomIDs(1000, 10)
+	for _, id := range ids {
+		f.Add(id)
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Filter
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		if !got.Has(id) {
+			t.Fatalf("decoded Has(%v) = false for added ID", id)
+		}
+	}
+	if got.Count() != f.Count() || got.k != f.k || got.m != f.m {
+		t.Errorf("decoded filter = k:%d m:%d n:%d, want k:%d m:%d n:%d", got.k, got.m, got.n, f.k, f.m, f.n)
+	}
+	probes := slices.Clone(data) // a probe count that would stall Add and Has
+	binary.BigEndian.PutUint32(probes, 0xFFFFFFFF)
+	for _, b := range [][]byte{nil, data[:filterHeaderLen], data[:len(data)-1], probes} {
+		if err := got.UnmarshalBinary(b); err != ErrInvalidFilter {
+			t.Errorf("UnmarshalBinary(len %d) err=%v, want %v", len(b), err, ErrInvalidFilter)
+		}
+	}
+}
+
+var benchResultBool bool
+
+func BenchmarkFilterHas(b *testing.B) {
+	f := NewFilter(1_000_000, 0.01)
+	ids := randomIDs(1024, 60)
+	for _, id := range ids[:512] {
+		f.Add(id)
+	}
+	var r bool
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r = f.Has(ids[i%len(ids)])
+	}
+	benchResultBool = r
+}
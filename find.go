@@ -0,0 +1,57 @@
+package rid
+
+import "iter"
+
+// Find returns the first ID found in b and its offset, or a nil ID and -1 if
+// there is none. An ID is found where a run of exactly 16 letters and digits,
+// bounded by the start or end of b or any other byte, is a valid encoded ID;
+// IDs are not found within longer words.
+//
+// Find scans b once using the decoding table, suiting it to searching large
+// volumes of text such as logs.
+func Find(b []byte) (ID, int) {
+	return find(b, 0)
+}
+
+// FindAll returns an iterator over the offsets and IDs of every ID found in
+// b, as by Find.
+func FindAll(b []byte) iter.Seq2[int, ID] {
+	return func(yield func(int, ID) bool) {
+		for i := 0; ; i += encodedLen {
+			id, at := find(b, i)
+			if at < 0 || !yield(at, id) {
+				return
+			}
+			i = at
+		}
+	}
+}
+
+// find returns the first ID in b at or after offset start.
+func find(b []byte, start int) (ID, int) {
+	for i := start; i < len(b); {
+		if !isAlnum(b[i]) {
+			i++
+			continue
+		}
+		// consume the word, noting any character not in the charset
+		j, valid := i, true
+		for ; j < len(b) && isAlnum(b[j]); j++ {
+			if dec[b[j]] == maxByte {
+				valid = false
+			}
+		}
+		if valid && j-i == encodedLen {
+			var id ID
+			decode(&id, b[i:j])
+			return id, i
+		}
+		i = j
+	}
+
+	return nilID, -1
+}
+
+func isAlnum(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,96 @@
+package rid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	id, err := FromString("dfp9lmz9ksw87w48")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		text string
+		at   int
+	}{
+		{"dfp9lmz9ksw87w48", 0},
+		{"req id=dfp9lmz9ksw87w48 ok", 7},
+		{"[dfp9lmz9ksw87w48]", 1},
+		{"user_dfp9lmz9ksw87w48.", 5},
+		{"xdfp9lmz9ksw87w48", -1}, // within a longer word
+		{"dfp9lmz9ksw87w48x", -1}, // within a longer word
+		{"dfp9lmz9ksw87w4", -1},   // too short
+		{"dfp9lmz9ksw87w4a", -1},  // a is not in the charset
+		{"DFP9LMZ9KSW87W48", -1},  // upper case is not decoded
+		{"abcdefghijklmnop dfp9lmz9ksw87w48", 17},
+		{"", -1},
+	}
+	for _, tt := range tests {
+		got, at := Find([]byte(tt.text))
+		if at != tt.at {
+			t.Errorf("Find(%q) offset = %d, want %d", tt.text, at, tt.at)
+			continue
+		}
+		want := nilID
+		if at >= 0 {
+			want = id
+		}
+		if got != want {
+			t.Errorf("Find(%q) = %v, want %v", tt.text, got, want)
+		}
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	ids := randomIDs(100, 60)
+	var sb strings.Builder
+	for i, id := range ids {
+		sb.WriteString(strings.Repeat("x", i%3))
+		sb.WriteString(" ")
+		sb.WriteString(id.String())
+		if i%2 == 0 {
+			sb.WriteString(",")
+		} else {
+			sb.WriteString("\n")
+		}
+	}
+	text := []byte(sb.String())
+
+	i := 0
+	for at, id := range FindAll(text) {
+		if id != ids[i] {
+			t.Fatalf("FindAll() #%d = %v, want %v", i, id, ids[i])
+		}
+		if string(text[at:at+encodedLen]) != id.String() {
+			t.Fatalf("FindAll() #%d offset %d does not hold %v", i, at, id)
+		}
+		i++
+	}
+	if i != len(ids) {
+		t.Errorf("FindAll() found %d IDs, want %d", i, len(ids))
+	}
+
+	// adjacent IDs separated by one byte, and stopping early
+	text = []byte(ids[0].String() + "-" + ids[1].String() + "-" + ids[2].String())
+	n := 0
+	for range FindAll(text) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("FindAll() yielded %d IDs before break, want 2", n)
+	}
+}
+
+func BenchmarkFindAll(b *testing.B) {
+	line := []byte("2023-01-02T15:04:05Z INFO request id=dfp9lmz9ksw87w48 user=example path=/api/v1/items status=200\n")
+	text := []byte(strings.Repeat(string(line), 1000))
+	b.SetBytes(int64(len(text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range FindAll(text) {
+		}
+	}
+}
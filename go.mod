@@ -1,6 +1,6 @@
 module github.com/mwyvr/rid
 
-// required for math/rand/v2
-go 1.22
+// required for iter and range-over-func; math/rand/v2 requires 1.22
+go 1.23
 
 // if running anything under eval/* run `go mod tidy` to pull in dependencies.
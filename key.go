@@ -0,0 +1,215 @@
+package rid
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+// Key is a composite key for embedded databases and key-value stores such as
+// BoltDB, built by appending IDs, strings, integers and timestamps in an
+// order-preserving binary format: comparing two keys with bytes.Compare
+// gives the same result as comparing their components in turn.
+//
+//	var k rid.Key
+//	k = k.AppendString(tenant).AppendID(id).AppendUint(seq)
+//
+// Components are not tagged with their type; decode a key with a KeyDecoder,
+// reading components in the order they were appended.
+//
+// Encoding of each component:
+//
+//   - ID: the 10 raw bytes
+//   - string, []byte: 0x00 escaped as 0x00 0xFF, terminated by 0x00 0x01
+//   - uint64: 8 bytes big endian
+//   - int64: 8 bytes big endian with the sign bit flipped
+//   - time.Time: int64 seconds as above, then 4 bytes of nanoseconds
+type Key []byte
+
+const (
+	escape     = 0x00
+	escapedNul = 0xFF
+	terminator = 0x01
+)
+
+var (
+	// ErrInvalidKey is returned when a Key component cannot be decoded.
+	ErrInvalidKey = errors.New("rid: invalid key")
+)
+
+// AppendID appends the binary form of id to k and returns the extended key.
+func (k Key) AppendID(id ID) Key {
+	return append(k, id[:]...)
+}
+
+// AppendString appends s to k and returns the extended key.
+func (k Key) AppendString(s string) Key {
+	for i := 0; i < len(s); i++ {
+		if s[i] == escape {
+			k = append(k, escape, escapedNul)
+			continue
+		}
+		k = append(k, s[i])
+	}
+
+	return append(k, escape, terminator)
+}
+
+// AppendBytes appends b to k and returns the extended key. Byte slices are
+// encoded identically to strings.
+func (k Key) AppendBytes(b []byte) Key {
+	for _, c := range b {
+		if c == escape {
+			k = append(k, escape, escapedNul)
+			continue
+		}
+		k = append(k, c)
+	}
+
+	return append(k, escape, terminator)
+}
+
+// AppendUint appends u to k and returns the extended key.
+func (k Key) AppendUint(u uint64) Key {
+	return append(k,
+		byte(u>>56), byte(u>>48), byte(u>>40), byte(u>>32),
+		byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+}
+
+// AppendInt appends i to k and returns the extended key. Negative values
+// order before positive ones.
+func (k Key) AppendInt(i int64) Key {
+	return k.AppendUint(uint64(i) ^ 1<<63)
+}
+
+// AppendTime appends t, with nanosecond resolution, to k and returns the
+// extended key. The location of t is not recorded.
+func (k Key) AppendTime(t time.Time) Key {
+	k = k.AppendInt(t.Unix())
+	ns := uint32(t.Nanosecond())
+
+	return append(k, byte(ns>>24), byte(ns>>16), byte(ns>>8), byte(ns))
+}
+
+// PrefixEnd returns the smallest key greater than every key having k as a
+// prefix, suitable as the exclusive upper bound of a range scan. A nil Key is
+// returned if no such key exists, i.e. k is empty or all 0xFF bytes.
+func (k Key) PrefixEnd() Key {
+	end := bytes.Clone(k)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < maxByte {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
+
+// KeyDecoder reads the components of a Key in the order they were appended.
+type KeyDecoder struct {
+	b []byte
+}
+
+// NewKeyDecoder returns a KeyDecoder reading from k. The decoder does not
+// copy k; callers must not modify k while decoding.
+func NewKeyDecoder(k []byte) *KeyDecoder {
+	return &KeyDecoder{b: k}
+}
+
+// Len returns the number of undecoded bytes remaining.
+func (d *KeyDecoder) Len() int {
+	return len(d.b)
+}
+
+// ReadID decodes an ID component.
+func (d *KeyDecoder) ReadID() (ID, error) {
+	var id ID
+	if len(d.b) < rawLen {
+		return nilID, ErrInvalidKey
+	}
+
+	copy(id[:], d.b)
+	d.b = d.b[rawLen:]
+
+	return id, nil
+}
+
+// ReadString decodes a string component.
+func (d *KeyDecoder) ReadString() (string, error) {
+	b, err := d.ReadBytes()
+
+	return string(b), err
+}
+
+// ReadBytes decodes a []byte component into a newly allocated slice.
+func (d *KeyDecoder) ReadBytes() ([]byte, error) {
+	var out []byte
+	for i := 0; i < len(d.b); i++ {
+		c := d.b[i]
+		if c != escape {
+			out = append(out, c)
+			continue
+		}
+		if i+1 == len(d.b) {
+			break
+		}
+		switch d.b[i+1] {
+		case escapedNul:
+			out = append(out, escape)
+			i++
+		case terminator:
+			d.b = d.b[i+2:]
+			if out == nil {
+				out = []byte{}
+			}
+			return out, nil
+		default:
+			return nil, ErrInvalidKey
+		}
+	}
+
+	return nil, ErrInvalidKey
+}
+
+// ReadUint decodes a uint64 component.
+func (d *KeyDecoder) ReadUint() (uint64, error) {
+	if len(d.b) < 8 {
+		return 0, ErrInvalidKey
+	}
+
+	b := d.b[:8]
+	d.b = d.b[8:]
+
+	// Big Endian
+	return uint64(b[0])<<56 | uint64(b[1])<<48 | uint64(b[2])<<40 | uint64(b[3])<<32 |
+		uint64(b[4])<<24 | uint64(b[5])<<16 | uint64(b[6])<<8 | uint64(b[7]), nil
+}
+
+// ReadInt decodes an int64 component.
+func (d *KeyDecoder) ReadInt() (int64, error) {
+	u, err := d.ReadUint()
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(u ^ 1<<63), nil
+}
+
+// ReadTime decodes a time.Time component. The returned time is in the local
+// location, consistent with ID.Time.
+func (d *KeyDecoder) ReadTime() (time.Time, error) {
+	if len(d.b) < 12 {
+		return time.Time{}, ErrInvalidKey
+	}
+
+	sec, _ := d.ReadInt()
+	b := d.b[:4]
+	d.b = d.b[4:]
+	ns := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+	if ns >= int64(time.Second) {
+		return time.Time{}, ErrInvalidKey
+	}
+
+	return time.Unix(sec, ns), nil
+}
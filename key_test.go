@@ -0,0 +1,116 @@
+package rid
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestKeyRoundTrip(t *testing.T) {
+	id := IDs[0].id
+	ts := time.Date(2023, time.March, 2, 10, 4, 5, 123456789, time.UTC)
+	k := Key(nil).
+		AppendString("tenant\x00one").
+		AppendID(id).
+		AppendInt(-42).
+		AppendUint(math.MaxUint64).
+		AppendBytes(nil).
+		AppendTime(ts)
+
+	d := NewKeyDecoder(k)
+	if got, err := d.ReadString(); err != nil || got != "tenant\x00one" {
+		t.Errorf("ReadString() = %q, %v", got, err)
+	}
+	if got, err := d.ReadID(); err != nil || got != id {
+		t.Errorf("ReadID() = %v, %v, want %v", got, err, id)
+	}
+	if got, err := d.ReadInt(); err != nil || got != -42 {
+		t.Errorf("ReadInt() = %v, %v", got, err)
+	}
+	if got, err := d.ReadUint(); err != nil || got != math.MaxUint64 {
+		t.Errorf("ReadUint() = %v, %v", got, err)
+	}
+	if got, err := d.ReadBytes(); err != nil || len(got) != 0 {
+		t.Errorf("ReadBytes() = %v, %v", got, err)
+	}
+	if got, err := d.ReadTime(); err != nil || !got.Equal(ts) {
+		t.Errorf("ReadTime() = %v, %v, want %v", got, err, ts)
+	}
+	if d.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", d.Len())
+	}
+}
+
+func TestKeyOrdering(t *testing.T) {
+	// each pair of keys is in ascending logical order
+	pairs := []struct {
+		name        string
+		left, right Key
+	}{
+		{"id", Key(nil).AppendID(IDs[2].id), Key(nil).AppendID(IDs[3].id)},
+		{"string prefix", Key(nil).AppendString("a"), Key(nil).AppendString("ab")},
+		{"string nul", Key(nil).AppendString("a"), Key(nil).AppendString("a\x00")},
+		{"string then id", Key(nil).AppendString("a").AppendID(IDs[1].id), Key(nil).AppendString("ab").AppendID(IDs[2].id)},
+		{"int sign", Key(nil).AppendInt(-1), Key(nil).AppendInt(0)},
+		{"int min", Key(nil).AppendInt(math.MinInt64), Key(nil).AppendInt(-1)},
+		{"uint", Key(nil).AppendUint(255), Key(nil).AppendUint(256)},
+		{"time before epoch", Key(nil).AppendTime(time.Unix(-1, 0)), Key(nil).AppendTime(time.Unix(0, 0))},
+		{"time nanos", Key(nil).AppendTime(time.Unix(5, 1)), Key(nil).AppendTime(time.Unix(5, 2))},
+		{"id then suffix", Key(nil).AppendID(IDs[3].id).AppendString("z"), Key(nil).AppendID(IDs[0].id).AppendString("a")},
+	}
+	for _, p := range pairs {
+		if bytes.Compare(p.left, p.right) >= 0 {
+			t.Errorf("%s: %x not less than %x", p.name, p.left, p.right)
+		}
+	}
+}
+
+func TestKeyPrefixEnd(t *testing.T) {
+	prefix := Key(nil).AppendString("tenant")
+	end := prefix.PrefixEnd()
+	inside := prefix.AppendID(IDs[1].id)
+	if bytes.Compare(inside, end) >= 0 {
+		t.Errorf("%x not less than PrefixEnd() %x", inside, end)
+	}
+	if bytes.Compare(end, Key(nil).AppendString("tenant0")) > 0 {
+		t.Errorf("PrefixEnd() %x past next prefix", end)
+	}
+	if got := (Key{0x01, 0xff, 0xff}).PrefixEnd(); !bytes.Equal(got, Key{0x02}) {
+		t.Errorf("PrefixEnd() = %x, want 02", got)
+	}
+	if got := (Key{0xff}).PrefixEnd(); got != nil {
+		t.Errorf("PrefixEnd() = %x, want nil", got)
+	}
+}
+
+func TestKeyDecoderErrors(t *testing.T) {
+	if _, err := NewKeyDecoder([]byte{0x1, 0x2}).ReadID(); err != ErrInvalidKey {
+		t.Errorf("ReadID() err=%v, want %v", err, ErrInvalidKey)
+	}
+	if _, err := NewKeyDecoder([]byte("abc")).ReadString(); err != ErrInvalidKey {
+		t.Errorf("ReadString() unterminated err=%v, want %v", err, ErrInvalidKey)
+	}
+	if _, err := NewKeyDecoder([]byte{'a', 0x00, 0x02}).ReadString(); err != ErrInvalidKey {
+		t.Errorf("ReadString() bad escape err=%v, want %v", err, ErrInvalidKey)
+	}
+	if _, err := NewKeyDecoder([]byte{0x1}).ReadInt(); err != ErrInvalidKey {
+		t.Errorf("ReadInt() err=%v, want %v", err, ErrInvalidKey)
+	}
+	if _, err := NewKeyDecoder(make([]byte, 11)).ReadTime(); err != ErrInvalidKey {
+		t.Errorf("ReadTime() err=%v, want %v", err, ErrInvalidKey)
+	}
+}
+
+func ExampleKey() {
+	id, _ := FromString("dfp7emzzzzy30ey2")
+	k := Key(nil).AppendString("acme").AppendID(id).AppendUint(7)
+
+	d := NewKeyDecoder(k)
+	tenant, _ := d.ReadString()
+	got, _ := d.ReadID()
+	seq, _ := d.ReadUint()
+	fmt.Println(tenant, got, seq)
+	// Output: acme dfp7emzzzzy30ey2 7
+}
@@ -0,0 +1,114 @@
+package rid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEncodeListRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []ID
+	}{
+		{"empty", nil},
+		{"single", []ID{IDs[1].id}},
+		{"fixtures", []ID{IDList[2], IDList[3], IDList[0], IDList[5], IDList[4], IDList[1]}},
+		{"duplicates", []ID{IDs[0].id, IDs[0].id, IDs[1].id}},
+		{"dense", randomIDs(10000, 3)},
+		{"nil IDs", []ID{nilID, nilID, IDs[0].id}},
+		{"one second", randomIDs(1000, 1)},
+		{"sparse", randomIDs(1000, 1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortFast(tt.ids)
+			b, err := EncodeList(tt.ids)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := DecodeList(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.ids) {
+				t.Errorf("DecodeList(EncodeList()) differs\ngot %v\nwant %v", got, tt.ids)
+			}
+		})
+	}
+}
+
+func TestEncodeListUnsorted(t *testing.T) {
+	if _, err := EncodeList(IDList); err != ErrUnsortedList {
+		t.Errorf("EncodeList() err=%v, want %v", err, ErrUnsortedList)
+	}
+}
+
+func TestDecodeListInvalid(t *testing.T) {
+	ids := randomIDs(100, 10)
+	SortFast(ids)
+	b, _ := EncodeList(ids)
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"empty", nil},
+		{"truncated", b[:len(b)-1]},
+		{"trailing", append(slices.Clone(b), 0x0)},
+		{"count too large", []byte{0xff, 0x01, 0x0, 0x0}},
+		// timestamp delta 0xFFFFFFFF, then 1
+		{"timestamp overflow", []byte{0x2, 0xfe, 0xff, 0xff, 0xff, 0x1f, 0, 0, 0, 0, 0, 0, 0x2, 0, 0, 0, 0, 0, 0}},
+		// a second group with the same timestamp
+		{"repeated timestamp", []byte{0x2, 0x2, 0, 0, 0, 0, 0, 0, 0x0, 0, 0, 0, 0, 0, 0}},
+		// a group of two, the Rice coded difference 1 exceeding maxRandom
+		{"random overflow", []byte{0x2, 0x1, 0x1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x0, 0x80}},
+		{"group too large", []byte{0x2, 0x1, 0x2, 0, 0, 0, 0, 0, 0, 0x0, 0x0}},
+		{"rice parameter", []byte{0x2, 0x1, 0x1, 0, 0, 0, 0, 0, 0, 48, 0x0}},
+		{"rice truncated", []byte{0x2, 0x1, 0x1, 0, 0, 0, 0, 0, 0, 0x0, 0xff}},
+	}
+	for _, tt := range tests {
+		if _, err := DecodeList(tt.b); err != ErrInvalidList {
+			t.Errorf("%s: DecodeList() err=%v, want %v", tt.name, err, ErrInvalidList)
+		}
+	}
+}
+
+// benchmarkList reports the encoded size relative to raw 10-byte packing
+func benchmarkList(b *testing.B, n int, seconds int64) {
+	ids := randomIDs(n, seconds)
+	SortFast(ids)
+	var enc []byte
+	b.SetBytes(int64(n * rawLen))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		enc, _ = EncodeList(ids)
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(len(enc))/float64(n*rawLen), "ratio")
+	b.ReportMetric(float64(len(enc))/float64(n), "B/id")
+}
+
+// one ID every ten seconds
+func BenchmarkEncodeListSparse(b *testing.B) {
+	benchmarkList(b, 100_000, 1_000_000)
+}
+
+// ten IDs per second
+func BenchmarkEncodeListDense(b *testing.B) {
+	benchmarkList(b, 100_000, 10_000)
+}
+
+// a thousand IDs per second
+func BenchmarkEncodeListBurst(b *testing.B) {
+	benchmarkList(b, 100_000, 100)
+}
+
+func BenchmarkDecodeList(b *testing.B) {
+	ids := randomIDs(100_000, 10_000)
+	SortFast(ids)
+	enc, _ := EncodeList(ids)
+	b.SetBytes(int64(len(ids) * rawLen))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = DecodeList(enc)
+	}
+}
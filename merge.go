@@ -0,0 +1,98 @@
+package rid
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// Merge returns an iterator merging seqs, each of which must yield IDs in
+// ascending order, into a single ascending sequence. Identical IDs appearing
+// in more than one sequence are all yielded; see MergeUnique.
+//
+// Merge is a k-way merge holding only one ID per sequence in memory, so it
+// suits sequences far larger than memory, such as per-shard exports.
+func Merge(seqs ...iter.Seq[ID]) iter.Seq[ID] {
+	return merge(seqs, false)
+}
+
+// MergeUnique is Merge, yielding each distinct ID only once.
+func MergeUnique(seqs ...iter.Seq[ID]) iter.Seq[ID] {
+	return merge(seqs, true)
+}
+
+func merge(seqs []iter.Seq[ID], unique bool) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		h := make(mergeHeap, 0, len(seqs))
+		defer func() {
+			for _, c := range h {
+				c.stop()
+			}
+		}()
+
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			if id, ok := next(); ok {
+				h = append(h, &cursor{id: id, seq: i, next: next, stop: stop})
+			} else {
+				stop()
+			}
+		}
+		heap.Init(&h)
+
+		var last ID
+		first := true
+		for len(h) > 0 {
+			c := h[0]
+			if !unique || first || c.id != last {
+				if !yield(c.id) {
+					return
+				}
+				last, first = c.id, false
+			}
+			if id, ok := c.next(); ok {
+				c.id = id
+				heap.Fix(&h, 0)
+			} else {
+				c.stop()
+				heap.Pop(&h)
+			}
+		}
+	}
+}
+
+// cursor is the head of one sequence being merged.
+type cursor struct {
+	id   ID
+	seq  int // position in the arguments, breaking ties for a stable merge
+	next func() (ID, bool)
+	stop func()
+}
+
+type mergeHeap []*cursor
+
+func (h mergeHeap) Len() int {
+	return len(h)
+}
+
+func (h mergeHeap) Less(i, j int) bool {
+	if c := h[i].id.Compare(h[j].id); c != 0 {
+		return c < 0
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h mergeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *mergeHeap) Push(x any) {
+	*h = append(*h, x.(*cursor))
+}
+
+func (h *mergeHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return c
+}
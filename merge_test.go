@@ -0,0 +1,61 @@
+package rid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	a := randomIDs(1000, 100)
+	b := randomIDs(500, 100)
+	c := slices.Clone(a[:100]) // duplicates across sequences
+	for _, s := range [][]ID{a, b, c} {
+		SortFast(s)
+	}
+
+	all := slices.Concat(a, b, c)
+	SortFast(all)
+	got := slices.Collect(Merge(slices.Values(a), slices.Values(b), slices.Values([]ID(nil)), slices.Values(c)))
+	if !slices.Equal(got, all) {
+		t.Error("Merge() differs from sorting the concatenated input")
+	}
+
+	got = slices.Collect(MergeUnique(slices.Values(a), slices.Values(b), slices.Values(c)))
+	if want := slices.Compact(all); !slices.Equal(got, want) {
+		t.Error("MergeUnique() differs from sorted, deduplicated input")
+	}
+}
+
+func TestMergeEarlyStop(t *testing.T) {
+	stopped := 0
+	seq := func(ids []ID) func(func(ID) bool) {
+		return func(yield func(ID) bool) {
+			defer func() { stopped++ }()
+			for _, id := range ids {
+				if !yield(id) {
+					return
+				}
+			}
+		}
+	}
+	// sorted (ascending) should be IDs 2, 3, 0, 5, 4, 1
+	var got []ID
+	for id := range Merge(seq([]ID{IDs[2].id, IDs[0].id, IDs[1].id}), seq([]ID{IDs[3].id, IDs[5].id})) {
+		got = append(got, id)
+		if len(got) == 3 {
+			break
+		}
+	}
+	if want := []ID{IDs[2].id, IDs[3].id, IDs[0].id}; !slices.Equal(got, want) {
+		t.Errorf("\ngot %v\nwant %v\n", got, want)
+	}
+	if stopped != 2 {
+		t.Errorf("%d sequences stopped, want 2", stopped)
+	}
+}
+
+func TestMergeNone(t *testing.T) {
+	for range Merge() {
+		t.Error("Merge() of no sequences yielded an ID")
+	}
+}
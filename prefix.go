@@ -0,0 +1,141 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxCandidates limits the candidates held by an AmbiguousPrefixError
+const maxCandidates = 10
+
+var (
+	// ErrPrefixNotFound is returned by PrefixIndex.Resolve when no ID in the
+	// index begins with the prefix.
+	ErrPrefixNotFound = errors.New("rid: no id matches prefix")
+)
+
+// AmbiguousPrefixError is returned by PrefixIndex.Resolve when more than one
+// ID begins with the prefix.
+type AmbiguousPrefixError struct {
+	Prefix     string
+	Count      int  // number of matching IDs
+	Candidates []ID // the first, ascending, at most 10 of the matching IDs
+}
+
+func (e *AmbiguousPrefixError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rid: ambiguous prefix %q matches %d ids:", e.Prefix, e.Count)
+	for _, id := range e.Candidates {
+		fmt.Fprintf(&b, " %s", id)
+	}
+	if e.Count > len(e.Candidates) {
+		fmt.Fprintf(&b, " ...")
+	}
+
+	return b.String()
+}
+
+// PrefixIndex resolves abbreviated IDs, much like git resolves abbreviated
+// commit hashes. Prefixes are of the Base32 encoded form of an ID. Ordering
+// each character by its index in the character set, rather than by its byte
+// value, encoded IDs sort as their binary form does, so the IDs matching a
+// prefix are a contiguous run of the sorted index.
+//
+// A PrefixIndex is not safe for concurrent use.
+type PrefixIndex struct {
+	set Set
+}
+
+// NewPrefixIndex returns a PrefixIndex over ids.
+func NewPrefixIndex(ids ...ID) *PrefixIndex {
+	return &PrefixIndex{set: *NewSet(ids...)}
+}
+
+// Add adds id to the index, returning false if it was already present.
+func (x *PrefixIndex) Add(id ID) bool {
+	return x.set.Add(id)
+}
+
+// Len returns the number of IDs in the index.
+func (x *PrefixIndex) Len() int {
+	return x.set.Len()
+}
+
+// Shortest returns the shortest prefix of id's encoded form that no other ID
+// in the index shares. If id is not in the index, the prefix distinguishes id
+// from every indexed ID and so will not resolve.
+func (x *PrefixIndex) Shortest(id ID) string {
+	var text, other [encodedLen]byte
+	encode(text[:], id[:])
+
+	ids := x.set.ids
+	i, found := slices.BinarySearchFunc(ids, id, Compare)
+	n := 0
+	if i > 0 {
+		encode(other[:], ids[i-1][:])
+		n = max(n, commonPrefix(text[:], other[:]))
+	}
+	if found {
+		i++
+	}
+	if i < len(ids) {
+		encode(other[:], ids[i][:])
+		n = max(n, commonPrefix(text[:], other[:]))
+	}
+
+	return string(text[:min(n+1, encodedLen)])
+}
+
+// Resolve returns the ID in the index beginning with prefix. ErrInvalidID is
+// returned if prefix is not the start of a valid encoded ID,
+// ErrPrefixNotFound if no ID matches and an *AmbiguousPrefixError if more
+// than one does.
+func (x *PrefixIndex) Resolve(prefix string) (ID, error) {
+	if len(prefix) == 0 || len(prefix) > encodedLen {
+		return nilID, ErrInvalidID
+	}
+	for i := 0; i < len(prefix); i++ {
+		if dec[prefix[i]] == maxByte {
+			return nilID, ErrInvalidID
+		}
+	}
+
+	// every 16 character string decodes, so padding the prefix with the
+	// lowest and highest characters gives the bounds of the matching IDs
+	var lo, hi ID
+	pad := encodedLen - len(prefix)
+	decode(&lo, []byte(prefix+strings.Repeat(charset[:1], pad)))
+	decode(&hi, []byte(prefix+strings.Repeat(charset[len(charset)-1:], pad)))
+
+	ids := x.set.ids
+	i, _ := slices.BinarySearchFunc(ids, lo, Compare)
+	j, found := slices.BinarySearchFunc(ids, hi, Compare)
+	if found {
+		j++
+	}
+
+	switch j - i {
+	case 0:
+		return nilID, ErrPrefixNotFound
+	case 1:
+		return ids[i], nil
+	default:
+		return nilID, &AmbiguousPrefixError{
+			Prefix:     prefix,
+			Count:      j - i,
+			Candidates: slices.Clone(ids[i:min(j, i+maxCandidates)]),
+		}
+	}
+}
+
+// commonPrefix returns the length of the common prefix of a and b.
+func commonPrefix(a, b []byte) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+
+	return n
+}
@@ -0,0 +1,103 @@
+package rid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPrefixIndexResolve(t *testing.T) {
+	x := NewPrefixIndex(IDList...)
+	// dfp7emzzzzy30ey2 and dfp7em00001p0t5j share the prefix dfp7em
+	tests := []struct {
+		prefix string
+		want   ID
+		err    error
+	}{
+		{"dfp7emz", IDs[0].id, nil},
+		{"dfp7em0", IDs[3].id, nil},
+		{"z", IDs[1].id, nil},
+		{"0000000000000000", IDs[2].id, nil},
+		{"dgb58", IDs[4].id, nil},
+		{"dfp8", nilID, ErrPrefixNotFound},
+		{"", nilID, ErrInvalidID},
+		{"dfpu", nilID, ErrInvalidID},
+		{"DFP7", nilID, ErrInvalidID},
+		{"dfp7emzzzzy30ey22", nilID, ErrInvalidID},
+	}
+	for _, tt := range tests {
+		got, err := x.Resolve(tt.prefix)
+		if got != tt.want || err != tt.err {
+			t.Errorf("Resolve(%q) = %v, %v, want %v, %v", tt.prefix, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestPrefixIndexAmbiguous(t *testing.T) {
+	x := NewPrefixIndex(IDList...)
+	_, err := x.Resolve("dfp7em")
+	var ambiguous *AmbiguousPrefixError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Resolve() err=%v, want *AmbiguousPrefixError", err)
+	}
+	if ambiguous.Count != 2 || len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0] != IDs[3].id || ambiguous.Candidates[1] != IDs[0].id {
+		t.Errorf("Candidates = %v", ambiguous.Candidates)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "dfp7em00001p0t5j") || !strings.Contains(msg, "dfp7emzzzzy30ey2") {
+		t.Errorf("Error() = %q, want candidates listed", msg)
+	}
+	// the candidate list is truncated in the error message
+	ts := IDs[0].id.Time()
+	many := NewPrefixIndex()
+	for i := 0; i < maxCandidates*2; i++ {
+		many.Add(NewWithTime(ts))
+	}
+	_, err = many.Resolve("dfp7em")
+	if !errors.As(err, &ambiguous) || ambiguous.Count != maxCandidates*2 || len(ambiguous.Candidates) != maxCandidates {
+		t.Errorf("Resolve() err=%v, want %d of %d candidates", err, maxCandidates, maxCandidates*2)
+	}
+	if msg := err.Error(); !strings.HasSuffix(msg, "...") || strings.Count(msg, " dfp7em") != maxCandidates {
+		t.Errorf("Error() = %q, want %d candidates then ...", msg, maxCandidates)
+	}
+}
+
+func TestPrefixIndexShortest(t *testing.T) {
+	x := NewPrefixIndex(IDList...)
+	tests := []struct {
+		id   ID
+		want string
+	}{
+		{IDs[0].id, "dfp7emz"},
+		{IDs[3].id, "dfp7em0"},
+		{IDs[1].id, "z"},
+		{IDs[2].id, "0"},
+		{IDs[4].id, "dgb58"},
+		{IDs[5].id, "dgb53"},
+	}
+	for _, tt := range tests {
+		got := x.Shortest(tt.id)
+		if got != tt.want {
+			t.Errorf("Shortest(%v) = %s, want %s", tt.id, got, tt.want)
+		}
+		if id, err := x.Resolve(got); err != nil || id != tt.id {
+			t.Errorf("Resolve(Shortest(%v)) = %v, %v", tt.id, id, err)
+		}
+	}
+	// an ID not in the index is distinguished from its neighbours
+	if got := x.Shortest(ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc3}); got != "dfp7emzzzzy30ey3" {
+		t.Errorf("Shortest(absent) = %s", got)
+	}
+	if got := NewPrefixIndex().Shortest(IDs[0].id); got != "d" {
+		t.Errorf("empty Shortest() = %s, want d", got)
+	}
+}
+
+func TestPrefixIndexRandom(t *testing.T) {
+	ids := randomIDs(10000, 2)
+	x := NewPrefixIndex(ids...)
+	for _, id := range ids {
+		if got, err := x.Resolve(x.Shortest(id)); err != nil || got != id {
+			t.Fatalf("Resolve(Shortest(%v)) = %v, %v", id, got, err)
+		}
+	}
+}
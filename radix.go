@@ -0,0 +1,117 @@
+package rid
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+const (
+	// below radixMinLen IDs a comparison sort is faster than the radix sort's
+	// fixed per-pass overhead
+	radixMinLen = 256
+	// minimum number of IDs per worker in SortFastParallel
+	radixMinChunk = 1 << 16
+)
+
+// SortFast sorts ids in place into ascending order, as defined by Compare.
+//
+// SortFast is a least-significant-digit radix sort over the fixed 10-byte
+// layout of an ID and is considerably faster than Sort for large slices; it
+// allocates a scratch buffer the size of ids. Passes over byte positions that
+// hold the same value in every ID, typical of the leading timestamp bytes, are
+// skipped.
+func SortFast(ids []ID) {
+	radixSort(ids, 1)
+}
+
+// SortFastParallel is SortFast using up to workers goroutines; if workers is
+// less than 1, runtime.GOMAXPROCS(0) is used. Small slices are sorted using a
+// single goroutine.
+func SortFastParallel(ids []ID, workers int) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if max := len(ids) / radixMinChunk; workers > max {
+		workers = max
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	radixSort(ids, workers)
+}
+
+func radixSort(ids []ID, workers int) {
+	n := len(ids)
+	if n < radixMinLen {
+		slices.SortFunc(ids, Compare)
+		return
+	}
+
+	var (
+		src, dst = ids, make([]ID, n)
+		chunk    = (n + workers - 1) / workers
+		counts   = make([][256]int, workers)
+		wg       sync.WaitGroup
+	)
+
+	// run fn over each worker's chunk of src, concurrently if workers > 1
+	each := func(fn func(w, lo, hi int)) {
+		if workers == 1 {
+			fn(0, 0, n)
+			return
+		}
+		for w := 0; w < workers; w++ {
+			lo, hi := w*chunk, min((w+1)*chunk, n)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				fn(w, lo, hi)
+			}()
+		}
+		wg.Wait()
+	}
+
+	for pos := rawLen - 1; pos >= 0; pos-- {
+		each(func(w, lo, hi int) {
+			c := &counts[w]
+			*c = [256]int{}
+			for i := lo; i < hi; i++ {
+				c[src[i][pos]]++
+			}
+		})
+
+		// skip the pass if every ID has the same byte at pos; otherwise
+		// convert counts to starting offsets, per worker, preserving stability
+		skip := false
+		offset := 0
+		for v := 0; v < 256 && !skip; v++ {
+			total := 0
+			for w := range counts {
+				c := counts[w][v]
+				counts[w][v] = offset + total
+				total += c
+			}
+			skip = total == n
+			offset += total
+		}
+		if skip {
+			continue
+		}
+
+		each(func(w, lo, hi int) {
+			c := &counts[w]
+			for i := lo; i < hi; i++ {
+				v := src[i][pos]
+				dst[c[v]] = src[i]
+				c[v]++
+			}
+		})
+		src, dst = dst, src
+	}
+
+	if &src[0] != &ids[0] {
+		copy(ids, src)
+	}
+}
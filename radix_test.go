@@ -0,0 +1,101 @@
+package rid
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// randomIDs returns n IDs spread over the given number of seconds, in random
+// order, with a few duplicates.
+func randomIDs(n int, seconds int64) []ID {
+	ids := make([]ID, n)
+	start := time.Now().Unix()
+	for i := range ids {
+		ids[i] = NewWithTime(time.Unix(start+rand.Int64N(seconds), 0))
+	}
+	for i := 0; i < n/100; i++ {
+		ids[rand.IntN(n)] = ids[rand.IntN(n)]
+	}
+
+	return ids
+}
+
+func TestSortFast(t *testing.T) {
+	for _, n := range []int{0, 1, 2, radixMinLen - 1, radixMinLen, 10000, 3*radixMinChunk + 7} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			ids := randomIDs(n, 3600)
+			want := slices.Clone(ids)
+			slices.SortFunc(want, Compare)
+
+			got := slices.Clone(ids)
+			SortFast(got)
+			if !slices.Equal(got, want) {
+				t.Error("SortFast() result differs from slices.SortFunc()")
+			}
+			got = slices.Clone(ids)
+			SortFastParallel(got, 4)
+			if !slices.Equal(got, want) {
+				t.Error("SortFastParallel() result differs from slices.SortFunc()")
+			}
+		})
+	}
+}
+
+func TestSortFastSkippedPasses(t *testing.T) {
+	// identical timestamps, and IDs differing only in their last byte, leave
+	// most passes skipped; the result must still land in ids
+	ids := make([]ID, 1000)
+	for i := range ids {
+		ids[i] = ID{0x63, 0xac, 0x76, 0xd3, 0, 0, 0, 0, 0, byte(len(ids) - i)}
+	}
+	SortFast(ids)
+	if !slices.IsSortedFunc(ids, Compare) {
+		t.Error("SortFast() not sorted")
+	}
+	SortFast(ids) // already sorted; every pass but one skipped
+	if !slices.IsSortedFunc(ids, Compare) {
+		t.Error("SortFast() not sorted")
+	}
+}
+
+func TestSortFastParallelWorkers(t *testing.T) {
+	ids := randomIDs(2*radixMinChunk, 60)
+	SortFastParallel(ids, 0) // GOMAXPROCS
+	if !slices.IsSortedFunc(ids, Compare) {
+		t.Error("SortFastParallel(0) not sorted")
+	}
+}
+
+// generated on first use only, keeping the cost out of ordinary test runs
+var benchSortIDs = sync.OnceValue(func() []ID { return randomIDs(1_000_000, 24*3600) })
+
+func benchmarkSort(b *testing.B, sortFn func([]ID)) {
+	data := benchSortIDs()
+	ids := make([]ID, len(data))
+	b.SetBytes(int64(len(ids) * rawLen))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(ids, data)
+		sortFn(ids)
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	benchmarkSort(b, Sort)
+}
+
+func BenchmarkSlicesSortFunc(b *testing.B) {
+	benchmarkSort(b, func(ids []ID) { slices.SortFunc(ids, Compare) })
+}
+
+func BenchmarkSortFast(b *testing.B) {
+	benchmarkSort(b, SortFast)
+}
+
+func BenchmarkSortFastParallel(b *testing.B) {
+	benchmarkSort(b, func(ids []ID) { SortFastParallel(ids, 0) })
+}
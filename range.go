@@ -0,0 +1,88 @@
+package rid
+
+import "time"
+
+// MinAt returns the smallest possible ID for the second containing t; its
+// random component is zero. Together with MaxAt it bounds every ID that
+// NewWithTime could return for t, making it suitable as the inclusive lower
+// bound of a range query.
+func MinAt(t time.Time) ID {
+	return atTimestamp(uint32(t.Unix()), 0x00)
+}
+
+// MaxAt returns the largest possible ID for the second containing t; every
+// bit of its random component is set.
+func MaxAt(t time.Time) ID {
+	return atTimestamp(uint32(t.Unix()), maxByte)
+}
+
+// atTimestamp returns an ID with timestamp s and every random byte set to fill.
+func atTimestamp(s uint32, fill byte) ID {
+	id := ID{byte(s >> 24), byte(s >> 16), byte(s >> 8), byte(s)}
+	for i := 4; i < rawLen; i++ {
+		id[i] = fill
+	}
+
+	return id
+}
+
+// Range is an inclusive range of IDs, [From, To], such as all IDs created
+// between two points in time.
+type Range struct {
+	From ID
+	To   ID
+}
+
+// NewRange returns the Range of all IDs with a timestamp in the seconds from
+// t1 to t2 inclusive.
+func NewRange(t1, t2 time.Time) Range {
+	return Range{From: MinAt(t1), To: MaxAt(t2)}
+}
+
+// IsEmpty returns true if r contains no IDs, i.e. From is greater than To.
+func (r Range) IsEmpty() bool {
+	return r.From.Compare(r.To) > 0
+}
+
+// Contains returns true if From <= id <= To.
+func (r Range) Contains(id ID) bool {
+	return r.From.Compare(id) <= 0 && id.Compare(r.To) <= 0
+}
+
+// Overlaps returns true if r and other have at least one ID in common.
+func (r Range) Overlaps(other Range) bool {
+	if r.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+
+	return r.From.Compare(other.To) <= 0 && other.From.Compare(r.To) <= 0
+}
+
+// Split divides r into consecutive, non-overlapping ranges, each covering at
+// most d of time. Boundaries are aligned to multiples of d since the Unix
+// epoch so that the same d always produces the same partitions; the first and
+// last ranges may therefore cover less than d.
+//
+// ID timestamps have seconds resolution; d is truncated to whole seconds, with
+// a minimum of one second. Split returns nil if r is empty.
+func (r Range) Split(d time.Duration) []Range {
+	if r.IsEmpty() {
+		return nil
+	}
+
+	step := int64(d / time.Second)
+	if step < 1 {
+		step = 1
+	}
+
+	var out []Range
+	from, last := r.From, r.To.Timestamp()
+	for {
+		end := (from.Timestamp()/step+1)*step - 1
+		if end >= last {
+			return append(out, Range{From: from, To: r.To})
+		}
+		out = append(out, Range{From: from, To: atTimestamp(uint32(end), maxByte)})
+		from = atTimestamp(uint32(end+1), 0x00)
+	}
+}
@@ -0,0 +1,83 @@
+package rid
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMinMaxAt(t *testing.T) {
+	ts := time.Unix(1672246995, 500)
+	min, max := MinAt(ts), MaxAt(ts)
+	if got, want := min, (ID{0x63, 0xac, 0x76, 0xd3, 0, 0, 0, 0, 0, 0}); got != want {
+		t.Errorf("MinAt() = %v, want %v", got, want)
+	}
+	if got, want := max, (ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); got != want {
+		t.Errorf("MaxAt() = %v, want %v", got, want)
+	}
+	r := Range{min, max}
+	for i := 0; i < 1000; i++ {
+		if id := NewWithTime(ts); !r.Contains(id) {
+			t.Fatalf("%v not within [MinAt, MaxAt]", id)
+		}
+	}
+	if r.Contains(NewWithTime(ts.Add(time.Second))) || r.Contains(NewWithTime(ts.Add(-time.Second))) {
+		t.Error("Range contains ID from a neighbouring second")
+	}
+}
+
+func TestRangeContainsOverlaps(t *testing.T) {
+	// sorted (ascending) should be IDs 2, 3, 0, 5, 4, 1
+	r := Range{IDs[3].id, IDs[5].id}
+	if !r.Contains(IDs[3].id) || !r.Contains(IDs[0].id) || !r.Contains(IDs[5].id) {
+		t.Error("Contains() false for an ID within range")
+	}
+	if r.Contains(IDs[2].id) || r.Contains(IDs[4].id) {
+		t.Error("Contains() true for an ID outside range")
+	}
+	tests := []struct {
+		name  string
+		other Range
+		want  bool
+	}{
+		{"inside", Range{IDs[0].id, IDs[0].id}, true},
+		{"touching end", Range{IDs[5].id, IDs[1].id}, true},
+		{"before", Range{IDs[2].id, IDs[2].id}, false},
+		{"after", Range{IDs[4].id, IDs[1].id}, false},
+		{"empty", Range{IDs[1].id, IDs[2].id}, false},
+	}
+	for _, tt := range tests {
+		if got := r.Overlaps(tt.other); got != tt.want {
+			t.Errorf("%s: Overlaps() = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.other.Overlaps(r); got != tt.want {
+			t.Errorf("%s: reverse Overlaps() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	t1 := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+	t2 := time.Date(2023, 1, 1, 12, 15, 0, 0, time.UTC)
+	r := NewRange(t1, t2)
+	got := r.Split(time.Hour)
+	want := []Range{
+		{MinAt(t1), MaxAt(time.Date(2023, 1, 1, 10, 59, 59, 0, time.UTC))},
+		{MinAt(time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)), MaxAt(time.Date(2023, 1, 1, 11, 59, 59, 0, time.UTC))},
+		{MinAt(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)), MaxAt(t2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() =\n%v\nwant\n%v", got, want)
+	}
+	// sub-second durations split by second
+	if got := NewRange(t1, t1.Add(2*time.Second)).Split(time.Millisecond); len(got) != 3 {
+		t.Errorf("Split(1ms) len = %d, want 3", len(got))
+	}
+	if got := (Range{IDs[1].id, IDs[2].id}).Split(time.Hour); got != nil {
+		t.Errorf("empty Split() = %v, want nil", got)
+	}
+	// single range when d spans the whole range
+	if got := r.Split(24 * time.Hour); !reflect.DeepEqual(got, []Range{r}) {
+		t.Errorf("Split(24h) = %v, want %v", got, []Range{r})
+	}
+}
@@ -0,0 +1,103 @@
+package rid
+
+import "time"
+
+// ReverseID is the bit-inverted form of an ID, for use as a key where
+// ascending byte order should yield the newest records first, e.g. walking a
+// BoltDB cursor from the start of a bucket.
+//
+// Both the timestamp and random components are inverted, so ascending byte
+// order of ReverseIDs is exactly the descending order of their IDs; see
+// ID.ReverseTime to invert the timestamp alone. The ordering holds for the
+// binary form only: the Base32 encoding shares ID's character set, in which k
+// precedes j, so encoded ReverseIDs do not sort by string comparison.
+type ReverseID [rawLen]byte
+
+// Reverse returns the reverse key form of id.
+func (id ID) Reverse() ReverseID {
+	var r ReverseID
+	for i := range id {
+		r[i] = ^id[i]
+	}
+
+	return r
+}
+
+// ReverseTime returns a copy of id with only the timestamp inverted, for keys
+// ordering the newest second first while IDs within a second keep their
+// ascending random order. The result is an ID whose Time is not meaningful;
+// calling ReverseTime on it again returns the original ID.
+func (id ID) ReverseTime() ID {
+	for i := 0; i < 4; i++ {
+		id[i] = ^id[i]
+	}
+
+	return id
+}
+
+// FromReverse converts a ReverseID back to its forward ID.
+func FromReverse(r ReverseID) ID {
+	return r.ID()
+}
+
+// ID returns the forward ID of r.
+func (r ReverseID) ID() ID {
+	var id ID
+	for i := range r {
+		id[i] = ^r[i]
+	}
+
+	return id
+}
+
+// ReverseFromString decodes a Base32-encoded ReverseID.
+func ReverseFromString(str string) (ReverseID, error) {
+	r := &ReverseID{}
+	err := r.UnmarshalText([]byte(str))
+
+	return *r, err
+}
+
+// ReverseFromBytes copies []bytes into a ReverseID value. As with FromBytes,
+// only a length-check is possible and performed.
+func ReverseFromBytes(b []byte) (ReverseID, error) {
+	var r ReverseID
+
+	if len(b) != rawLen {
+		return r, ErrInvalidID
+	}
+
+	copy(r[:], b)
+
+	return r, nil
+}
+
+// String returns r as a Base32 encoded string.
+func (r ReverseID) String() string {
+	text := make([]byte, encodedLen)
+	encode(text, r[:])
+	return string(text)
+}
+
+// Bytes returns the binary representation of r.
+func (r ReverseID) Bytes() []byte {
+	return r[:]
+}
+
+// Time returns the timestamp of the forward ID as a Time value.
+func (r ReverseID) Time() time.Time {
+	return r.ID().Time()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r ReverseID) MarshalText() ([]byte, error) {
+	text := make([]byte, encodedLen)
+	encode(text, r[:])
+
+	return text, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *ReverseID) UnmarshalText(text []byte) error {
+	return (*ID)(r).UnmarshalText(text)
+}
@@ -0,0 +1,86 @@
+package rid
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestReverseRoundTrip(t *testing.T) {
+	for _, v := range IDs {
+		r := v.id.Reverse()
+		if got := FromReverse(r); got != v.id {
+			t.Errorf("FromReverse(%v.Reverse()) = %v", v.id, got)
+		}
+		if got := r.ID(); got != v.id {
+			t.Errorf("ID() = %v, want %v", got, v.id)
+		}
+		if !r.Time().Equal(v.id.Time()) {
+			t.Errorf("Time() = %v, want %v", r.Time(), v.id.Time())
+		}
+		got, err := ReverseFromString(r.String())
+		if err != nil || got != r {
+			t.Errorf("ReverseFromString(%s) = %v, %v, want %v", r, got, err, r)
+		}
+		got, err = ReverseFromBytes(r.Bytes())
+		if err != nil || got != r {
+			t.Errorf("ReverseFromBytes() = %v, %v, want %v", got, err, r)
+		}
+	}
+	if got := IDs[2].id.Reverse().String(); got != "zzzzzzzzzzzzzzzz" {
+		t.Errorf("nil ID Reverse() = %s, want zzzzzzzzzzzzzzzz", got)
+	}
+}
+
+func TestReverseErrors(t *testing.T) {
+	if _, err := ReverseFromString("dfp7emm"); err != ErrInvalidID {
+		t.Errorf("ReverseFromString() err=%v, want %v", err, ErrInvalidID)
+	}
+	if _, err := ReverseFromBytes([]byte{0x1}); err != ErrInvalidID {
+		t.Errorf("ReverseFromBytes() err=%v, want %v", err, ErrInvalidID)
+	}
+}
+
+func TestReverseOrdering(t *testing.T) {
+	// sorted (ascending) should be IDs 2, 3, 0, 5, 4, 1 - reversed, 1, 4, 5, 0, 3, 2
+	keys := make([]ReverseID, 0, len(IDList))
+	for _, id := range IDList {
+		keys = append(keys, id.Reverse())
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+	got := make([]ID, 0, len(keys))
+	for _, r := range keys {
+		got = append(got, r.ID())
+	}
+	if want := []ID{IDList[1], IDList[4], IDList[5], IDList[0], IDList[3], IDList[2]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot %v\nwant %v\n", got, want)
+	}
+}
+
+func TestReverseTime(t *testing.T) {
+	for _, v := range IDs {
+		r := v.id.ReverseTime()
+		if got := r.ReverseTime(); got != v.id {
+			t.Errorf("%v.ReverseTime().ReverseTime() = %v", v.id, got)
+		}
+		if r.Random() != v.id.Random() {
+			t.Errorf("ReverseTime() Random() = %d, want %d", r.Random(), v.id.Random())
+		}
+	}
+
+	// newest second first, ascending random order within a second
+	ids := randomIDs(1000, 10)
+	keys := make([]ID, len(ids))
+	for i, id := range ids {
+		keys[i] = id.ReverseTime()
+	}
+	Sort(keys)
+	for i := 1; i < len(keys); i++ {
+		prev, cur := keys[i-1].ReverseTime(), keys[i].ReverseTime()
+		if cur.Timestamp() > prev.Timestamp() ||
+			cur.Timestamp() == prev.Timestamp() && cur.Random() < prev.Random() {
+			t.Fatalf("key %d: %v ordered after %v", i, cur, prev)
+		}
+	}
+}
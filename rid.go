@@ -18,7 +18,8 @@ Key features:
 
   - K-orderable in both binary and string representations
   - Encoded IDs are short (16 characters)
-  - Automatic (de)serialization for SQL and JSON
+  - Automatic (de)serialization for SQL and JSON; BinaryID stores IDs in SQL
+    as 10-byte binary rather than text
   - Scalable performance as cores increase; ID generation is fast and remains so
   - URL and human friendly Base32 encoding using a custom character set to
     avoid unintended rude words if humans are to be exposed to IDs
@@ -174,11 +175,22 @@ func (id ID) Timestamp() int64 {
 	return int64(uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3]))
 }
 
-// Time returns the ID's timestamp as a Time value.
+// Time returns the ID's timestamp as a Time value in the local time zone.
 func (id ID) Time() time.Time {
 	return time.Unix(id.Timestamp(), 0)
 }
 
+// TimeIn returns the ID's timestamp as a Time value in the location loc.
+// TimeIn panics if loc is nil.
+func (id ID) TimeIn(loc *time.Location) time.Time {
+	return time.Unix(id.Timestamp(), 0).In(loc)
+}
+
+// UTC returns the ID's timestamp as a Time value in UTC.
+func (id ID) UTC() time.Time {
+	return time.Unix(id.Timestamp(), 0).UTC()
+}
+
 // Random returns the random component of the ID.
 func (id ID) Random() uint64 {
 	b := id[4:]
@@ -273,11 +285,18 @@ func (id ID) Value() (driver.Value, error) {
 
 // Scan implements the sql.Scanner interface.
 // https://golang.org/pkg/database/sql/#Scanner
+//
+// Both the Base32 text form and the raw 10-byte binary form are accepted,
+// allowing columns to be migrated between TEXT and BLOB/bytea storage.
 func (id *ID) Scan(value interface{}) (err error) {
 	switch val := value.(type) {
 	case string:
 		return id.UnmarshalText([]byte(val))
 	case []byte:
+		if len(val) == rawLen {
+			copy(id[:], val)
+			return nil
+		}
 		return id.UnmarshalText(val)
 	case nil:
 		*id = nilID
@@ -318,20 +337,53 @@ func (id *ID) UnmarshalJSON(b []byte) error {
 	return id.UnmarshalText(b[1 : len(b)-1])
 }
 
-// Compare makes IDs k-sortable, returning an integer comparing only the first
-// 4 bytes of two IDs.
+// Compare returns an integer comparing all 10 bytes of two IDs, behaving just
+// like `bytes.Compare(id[:], other[:])`. This is a total order: only identical
+// IDs compare equal.
 //
 // Recall that an ID is comprized of a:
 //
 // - 4-byte timestamp
 // - 6-byte random value
 //
-// Otherwise, it behaves just like `bytes.Compare(b1[:], b2[:])`.
+// As the timestamp leads, ordering by Compare is k-sorted by time; use
+// CompareTime to compare only the timestamp component.
 //
 // The result will be 0 if two IDs are identical, -1 if current id is less than
 // the other one, and 1 if current id is greater than the other.
 func (id ID) Compare(other ID) int {
-	return bytes.Compare(id[:5], other[:5])
+	return bytes.Compare(id[:], other[:])
+}
+
+// CompareTime returns an integer comparing only the 4-byte timestamp
+// component of two IDs; IDs created within the same second compare equal.
+func (id ID) CompareTime(other ID) int {
+	return bytes.Compare(id[:4], other[:4])
+}
+
+// Less returns true if id orders before other.
+func (id ID) Less(other ID) bool {
+	return id.Compare(other) < 0
+}
+
+// Equal returns true if id and other are identical.
+func (id ID) Equal(other ID) bool {
+	return id == other
+}
+
+// Compare returns an integer comparing a and b; see ID.Compare. Its signature
+// suits slices.SortFunc, slices.BinarySearchFunc and similar:
+//
+//	slices.SortFunc(ids, rid.Compare)
+//	i, found := slices.BinarySearchFunc(ids, id, rid.Compare)
+func Compare(a, b ID) int {
+	return a.Compare(b)
+}
+
+// CompareTime returns an integer comparing only the timestamp components of a
+// and b; see ID.CompareTime.
+func CompareTime(a, b ID) int {
+	return a.CompareTime(b)
 }
 
 type sorter []ID
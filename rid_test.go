@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 )
@@ -71,6 +72,13 @@ func TestIDPartsExtraction(t *testing.T) {
 			if got, want := v.id.Time(), time.Unix(v.ts, 0); got != want {
 				t.Errorf("Time() = %v, want %v", got, want)
 			}
+			if got, want := v.id.UTC(), time.Unix(v.ts, 0).UTC(); got != want || got.Location() != time.UTC {
+				t.Errorf("UTC() = %v, want %v", got, want)
+			}
+			loc := time.FixedZone("UTC-8", -8*60*60)
+			if got := v.id.TimeIn(loc); !got.Equal(time.Unix(v.ts, 0)) || got.Location() != loc {
+				t.Errorf("TimeIn(%v) = %v, want %v", loc, got, time.Unix(v.ts, 0).In(loc))
+			}
 			if got, want := v.id.Timestamp(), v.ts; got != want {
 				t.Errorf("Time() = %v, want %v", got, want)
 			}
@@ -95,9 +103,7 @@ func TestNew(t *testing.T) {
 		// Test for uniqueness among all other generated ids
 		for j, tid := range ids {
 			if j != i {
-				// can't use ID.Compare for this test as it compares only the time
-				// component of IDs
-				if bytes.Equal(id[:], tid[:]) {
+				if id.Equal(tid) {
 					t.Errorf("generated ID is not unique (%d/%d)\n%v", i, j, ids)
 				}
 			}
@@ -412,6 +418,19 @@ func TestIDDriverScanByteFromDatabase(t *testing.T) {
 	}
 }
 
+func TestIDDriverScanBinary(t *testing.T) {
+	// dfp7emzzzzy30ey2 ts:1672246995 rnd:281474912761794 2022-12-28 09:03:15 -0800 PST ID{0x63,0xac,0x76,0xd3,0xff,0xff,0xfc,0x30,0x37,0xc2}
+	got := ID{}
+	err := got.Scan([]byte{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+	if got != want {
+		t.Errorf("Scan() = %v, want %v", got, want)
+	}
+}
+
 func TestFromBytes_InvalidBytes(t *testing.T) {
 	cases := []struct {
 		length     int
@@ -441,6 +460,8 @@ func TestCompare(t *testing.T) {
 		{IDs[0].id, IDs[0].id, 0},
 		{IDs[2].id, IDs[1].id, -1},
 		{IDs[5].id, IDs[4].id, -1},
+		// identical timestamp and first random byte, differing in the last byte
+		{ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0, 0, 0, 0, 0x1}, ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0, 0, 0, 0, 0x2}, -1},
 	}
 	for _, p := range pairs {
 		if p.expected != p.left.Compare(p.right) {
@@ -449,6 +470,59 @@ func TestCompare(t *testing.T) {
 		if -1*p.expected != p.right.Compare(p.left) {
 			t.Errorf("%s Compare to %s should return %d", p.right, p.left, -1*p.expected)
 		}
+		if p.expected != Compare(p.left, p.right) {
+			t.Errorf("Compare(%s, %s) should return %d", p.left, p.right, p.expected)
+		}
+		if got, want := p.left.Less(p.right), p.expected < 0; got != want {
+			t.Errorf("%s Less %s = %v, want %v", p.left, p.right, got, want)
+		}
+		if got, want := p.left.Equal(p.right), p.expected == 0; got != want {
+			t.Errorf("%s Equal %s = %v, want %v", p.left, p.right, got, want)
+		}
+	}
+}
+
+func TestCompareTime(t *testing.T) {
+	pairs := []struct {
+		left     ID
+		right    ID
+		expected int
+	}{
+		{IDs[1].id, IDs[0].id, 1},
+		{IDs[2].id, IDs[1].id, -1},
+		// same second, different random components
+		{ID{0x63, 0xac, 0x76, 0xd3, 0x0}, ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff}, 0},
+		{MinAt(IDs[0].id.Time()), MaxAt(IDs[0].id.Time()), 0},
+	}
+	for _, p := range pairs {
+		if got := p.left.CompareTime(p.right); got != p.expected {
+			t.Errorf("%s CompareTime to %s = %d, want %d", p.left, p.right, got, p.expected)
+		}
+		if got := CompareTime(p.right, p.left); got != -1*p.expected {
+			t.Errorf("CompareTime(%s, %s) = %d, want %d", p.right, p.left, got, -1*p.expected)
+		}
+	}
+}
+
+func TestSlicesIntegration(t *testing.T) {
+	ids := make([]ID, 0, 1000)
+	// many IDs within the same second exercise ordering beyond the timestamp
+	ts := time.Now()
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, NewWithTime(ts))
+	}
+	ids = append(ids, ids[:10]...) // duplicates
+	slices.SortFunc(ids, Compare)
+	if !slices.IsSortedFunc(ids, func(a, b ID) int { return bytes.Compare(a[:], b[:]) }) {
+		t.Fatal("slices.SortFunc(ids, Compare) not in byte order")
+	}
+	if got := len(slices.CompactFunc(ids, ID.Equal)); got != 1000 {
+		t.Errorf("deduped len = %d, want 1000", got)
+	}
+	want := ids[500]
+	i, found := slices.BinarySearchFunc(ids, want, Compare)
+	if !found || ids[i] != want {
+		t.Errorf("BinarySearchFunc() = %d, %v, want %v", i, found, want)
 	}
 }
 
@@ -603,3 +677,15 @@ func ExampleFromString() {
 	fmt.Println(id.Timestamp(), id.Random())
 	// Output: 1672246995 281474912761794
 }
+
+func ExampleID_TimeIn() {
+	id, err := FromString("dfp7emzzzzy30ey2")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(id.UTC())
+	fmt.Println(id.TimeIn(time.FixedZone("PST", -8*60*60)))
+	// Output:
+	// 2022-12-28 17:03:15 +0000 UTC
+	// 2022-12-28 09:03:15 -0800 PST
+}
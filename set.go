@@ -0,0 +1,173 @@
+package rid
+
+import (
+	"errors"
+	"iter"
+	"slices"
+)
+
+var (
+	// ErrInvalidSet is returned when decoding a malformed Set encoding.
+	ErrInvalidSet = errors.New("rid: invalid set encoding")
+)
+
+// Set is a sorted set of IDs stored as packed 10-byte values, using far less
+// memory than a map[ID]bool of the same size. Membership tests are binary
+// searches; set algebra operates by merging.
+//
+// Adding IDs in ascending order, as generated, is cheap; adding out of order
+// moves the IDs that follow. To build a large Set from unordered IDs use
+// NewSet, which sorts in bulk.
+//
+// The zero value is an empty set ready to use. A Set is not safe for
+// concurrent use.
+type Set struct {
+	ids []ID // ascending, no duplicates
+}
+
+// NewSet returns a Set holding ids; ids is not modified.
+func NewSet(ids ...ID) *Set {
+	s := &Set{ids: slices.Clone(ids)}
+	SortFast(s.ids)
+	s.ids = slices.Compact(s.ids)
+
+	return s
+}
+
+// Len returns the number of IDs in s.
+func (s *Set) Len() int {
+	return len(s.ids)
+}
+
+// Add adds id to s, returning false if it was already present.
+func (s *Set) Add(id ID) bool {
+	// fast path: IDs arriving in order are appended
+	if n := len(s.ids); n == 0 || s.ids[n-1].Compare(id) < 0 {
+		s.ids = append(s.ids, id)
+		return true
+	}
+
+	i, found := slices.BinarySearchFunc(s.ids, id, Compare)
+	if found {
+		return false
+	}
+	s.ids = slices.Insert(s.ids, i, id)
+
+	return true
+}
+
+// Remove removes id from s, returning false if it was not present.
+func (s *Set) Remove(id ID) bool {
+	i, found := slices.BinarySearchFunc(s.ids, id, Compare)
+	if !found {
+		return false
+	}
+	s.ids = slices.Delete(s.ids, i, i+1)
+
+	return true
+}
+
+// Has returns true if id is in s.
+func (s *Set) Has(id ID) bool {
+	_, found := slices.BinarySearchFunc(s.ids, id, Compare)
+	return found
+}
+
+// All returns an iterator over the IDs in s in ascending order.
+func (s *Set) All() iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		for _, id := range s.ids {
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new Set of the IDs in either s or other.
+func (s *Set) Union(other *Set) *Set {
+	a, b := s.ids, other.ids
+	out := make([]ID, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch c := a[0].Compare(b[0]); {
+		case c < 0:
+			out, a = append(out, a[0]), a[1:]
+		case c > 0:
+			out, b = append(out, b[0]), b[1:]
+		default:
+			out, a, b = append(out, a[0]), a[1:], b[1:]
+		}
+	}
+	out = append(out, a...)
+	out = append(out, b...)
+
+	return &Set{ids: slices.Clip(out)}
+}
+
+// Intersect returns a new Set of the IDs in both s and other.
+func (s *Set) Intersect(other *Set) *Set {
+	a, b := s.ids, other.ids
+	var out []ID
+	for len(a) > 0 && len(b) > 0 {
+		switch c := a[0].Compare(b[0]); {
+		case c < 0:
+			a = a[1:]
+		case c > 0:
+			b = b[1:]
+		default:
+			out, a, b = append(out, a[0]), a[1:], b[1:]
+		}
+	}
+
+	return &Set{ids: out}
+}
+
+// Difference returns a new Set of the IDs in s that are not in other.
+func (s *Set) Difference(other *Set) *Set {
+	a, b := s.ids, other.ids
+	var out []ID
+	for len(a) > 0 && len(b) > 0 {
+		switch c := a[0].Compare(b[0]); {
+		case c < 0:
+			out, a = append(out, a[0]), a[1:]
+		case c > 0:
+			b = b[1:]
+		default:
+			a, b = a[1:], b[1:]
+		}
+	}
+	out = append(out, a...)
+
+	return &Set{ids: out}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is the
+// concatenation of the binary form of each ID in ascending order.
+func (s *Set) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(s.ids)*rawLen)
+	for _, id := range s.ids {
+		b = append(b, id[:]...)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the
+// contents of s. ErrInvalidSet is returned if data is not a whole number of
+// IDs in strictly ascending order.
+func (s *Set) UnmarshalBinary(data []byte) error {
+	if len(data)%rawLen != 0 {
+		return ErrInvalidSet
+	}
+
+	ids := make([]ID, len(data)/rawLen)
+	for i := range ids {
+		copy(ids[i][:], data[i*rawLen:])
+		if i > 0 && ids[i-1].Compare(ids[i]) >= 0 {
+			return ErrInvalidSet
+		}
+	}
+	s.ids = ids
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package rid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetAddHasRemove(t *testing.T) {
+	var s Set
+	// sorted (ascending) should be IDs 2, 3, 0, 5, 4, 1
+	for _, id := range IDList {
+		if !s.Add(id) {
+			t.Errorf("Add(%v) = false, want true", id)
+		}
+	}
+	if s.Add(IDList[0]) {
+		t.Error("Add() of existing ID = true, want false")
+	}
+	if got, want := slices.Collect(s.All()), []ID{IDList[2], IDList[3], IDList[0], IDList[5], IDList[4], IDList[1]}; !slices.Equal(got, want) {
+		t.Errorf("\ngot %v\nwant %v\n", got, want)
+	}
+	for _, id := range IDList {
+		if !s.Has(id) {
+			t.Errorf("Has(%v) = false, want true", id)
+		}
+	}
+	if s.Has(New()) {
+		t.Error("Has() of absent ID = true")
+	}
+	if !s.Remove(IDList[0]) || s.Remove(IDList[0]) || s.Has(IDList[0]) || s.Len() != 5 {
+		t.Error("Remove() did not remove exactly once")
+	}
+}
+
+func TestNewSet(t *testing.T) {
+	ids := randomIDs(5000, 10)
+	s := NewSet(ids...)
+	want := slices.Clone(ids)
+	slices.SortFunc(want, Compare)
+	want = slices.Compact(want)
+	if got := slices.Collect(s.All()); !slices.Equal(got, want) {
+		t.Error("NewSet() contents differ from sorted, deduplicated input")
+	}
+	// early exit from iteration
+	for id := range s.All() {
+		if id != want[0] {
+			t.Errorf("first ID = %v, want %v", id, want[0])
+		}
+		break
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet(IDs[0].id, IDs[1].id, IDs[2].id, IDs[3].id)
+	b := NewSet(IDs[2].id, IDs[3].id, IDs[4].id, IDs[5].id)
+	tests := []struct {
+		name string
+		got  *Set
+		want *Set
+	}{
+		{"union", a.Union(b), NewSet(IDList...)},
+		{"intersect", a.Intersect(b), NewSet(IDs[2].id, IDs[3].id)},
+		{"difference", a.Difference(b), NewSet(IDs[0].id, IDs[1].id)},
+		{"difference reversed", b.Difference(a), NewSet(IDs[4].id, IDs[5].id)},
+		{"union empty", a.Union(&Set{}), a},
+		{"intersect empty", a.Intersect(&Set{}), &Set{}},
+	}
+	for _, tt := range tests {
+		if got, want := slices.Collect(tt.got.All()), slices.Collect(tt.want.All()); !slices.Equal(got, want) {
+			t.Errorf("%s:\ngot %v\nwant %v\n", tt.name, got, want)
+		}
+	}
+}
+
+func TestSetBinary(t *testing.T) {
+	s := NewSet(randomIDs(100, 5)...)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != s.Len()*rawLen {
+		t.Errorf("MarshalBinary() len = %d, want %d", len(data), s.Len()*rawLen)
+	}
+	var got Set
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Collect(got.All()), slices.Collect(s.All())) {
+		t.Error("UnmarshalBinary(MarshalBinary()) differs")
+	}
+	if err := got.UnmarshalBinary(data[:15]); err != ErrInvalidSet {
+		t.Errorf("UnmarshalBinary(short) err=%v, want %v", err, ErrInvalidSet)
+	}
+	unsorted := append(IDs[1].id.Bytes(), IDs[0].id.Bytes()...)
+	if err := got.UnmarshalBinary(unsorted); err != ErrInvalidSet {
+		t.Errorf("UnmarshalBinary(unsorted) err=%v, want %v", err, ErrInvalidSet)
+	}
+}
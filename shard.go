@@ -0,0 +1,64 @@
+package rid
+
+import (
+	"math/bits"
+	"time"
+)
+
+// Shard maps id uniformly to a shard in [0, n) using its random component,
+// so IDs from any period spread evenly across shards. Changing n moves most
+// IDs to a different shard; see JumpShard to minimise movement.
+//
+// Shard panics if n < 1.
+func (id ID) Shard(n int) int {
+	if n < 1 {
+		panic("rid: Shard: n must be positive")
+	}
+
+	// multiply-shift reduction of the 48 random bits scaled to 64
+	hi, _ := bits.Mul64(id.Random()<<16, uint64(n))
+
+	return int(hi)
+}
+
+// JumpShard maps id to a shard in [0, n) using jump consistent hashing of its
+// random component. When n grows to n+1, only 1/(n+1) of IDs move, each to
+// the new shard.
+//
+// JumpShard panics if n < 1.
+func (id ID) JumpShard(n int) int {
+	return JumpHash(id.Random(), n)
+}
+
+// JumpHash returns the bucket in [0, n) for key using the jump consistent
+// hash of Lamping and Veach, https://arxiv.org/abs/1406.2294.
+//
+// JumpHash panics if n < 1.
+func JumpHash(key uint64, n int) int {
+	if n < 1 {
+		panic("rid: JumpHash: n must be positive")
+	}
+
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+
+	return int(b)
+}
+
+// Bucket returns the index of the time bucket of width d containing id's
+// timestamp, counting from the Unix epoch: IDs created in the same hour have
+// the same Bucket(time.Hour).
+//
+// Bucket panics if d <= 0.
+func (id ID) Bucket(d time.Duration) int64 {
+	if d <= 0 {
+		panic("rid: Bucket: d must be positive")
+	}
+
+	// timestamps are at most 2^32 seconds, well within int64 nanoseconds
+	return id.Timestamp() * int64(time.Second) / int64(d)
+}
@@ -0,0 +1,112 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShard(t *testing.T) {
+	const n, count = 16, 160_000
+	shards := make([]int, n)
+	for _, id := range randomIDs(count, 60) {
+		s := id.Shard(n)
+		if s < 0 || s >= n {
+			t.Fatalf("Shard(%d) = %d, out of range", n, s)
+		}
+		shards[s]++
+	}
+	// each shard should hold about count/n IDs
+	for i, c := range shards {
+		if c < count/n*9/10 || c > count/n*11/10 {
+			t.Errorf("shard %d holds %d IDs, want about %d", i, c, count/n)
+		}
+	}
+	if got := IDs[1].id.Shard(n); got != n-1 {
+		t.Errorf("max ID Shard(%d) = %d, want %d", n, got, n-1)
+	}
+	if got := IDs[2].id.Shard(n); got != 0 {
+		t.Errorf("nil ID Shard(%d) = %d, want 0", n, got)
+	}
+}
+
+func TestJumpShard(t *testing.T) {
+	ids := randomIDs(10_000, 60)
+	for _, id := range ids {
+		if got := id.JumpShard(1); got != 0 {
+			t.Fatalf("JumpShard(1) = %d, want 0", got)
+		}
+	}
+	// growing from 10 to 11 shards moves about 1/11 of IDs, all to shard 10
+	moved := 0
+	for _, id := range ids {
+		before, after := id.JumpShard(10), id.JumpShard(11)
+		if before != after {
+			moved++
+			if after != 10 {
+				t.Fatalf("ID moved from shard %d to %d, want 10", before, after)
+			}
+		}
+	}
+	if want := len(ids) / 11; moved < want*8/10 || moved > want*12/10 {
+		t.Errorf("%d IDs moved, want about %d", moved, want)
+	}
+}
+
+func TestJumpHash(t *testing.T) {
+	// test vectors shared by common implementations of the algorithm
+	tests := []struct {
+		key  uint64
+		n    int
+		want int
+	}{
+		{1, 1, 0},
+		{42, 57, 43},
+		{0xDEAD10CC, 1, 0},
+		{0xDEAD10CC, 666, 361},
+		{256, 1024, 520},
+	}
+	for _, tt := range tests {
+		if got := JumpHash(tt.key, tt.n); got != tt.want {
+			t.Errorf("JumpHash(%d, %d) = %d, want %d", tt.key, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestBucket(t *testing.T) {
+	ts := time.Date(2023, 1, 27, 14, 47, 27, 0, time.UTC)
+	id := NewWithTime(ts)
+	tests := []struct {
+		d    time.Duration
+		want int64
+	}{
+		{time.Second, ts.Unix()},
+		{time.Hour, ts.Unix() / 3600},
+		{24 * time.Hour, ts.Unix() / 86400},
+		{time.Millisecond, ts.Unix() * 1000},
+	}
+	for _, tt := range tests {
+		if got := id.Bucket(tt.d); got != tt.want {
+			t.Errorf("Bucket(%s) = %d, want %d", tt.d, got, tt.want)
+		}
+	}
+	if IDs[1].id.Bucket(time.Second) != IDs[1].ts {
+		t.Error("max ID Bucket(time.Second) overflowed")
+	}
+}
+
+func TestShardPanics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"Shard":    func() { IDs[0].id.Shard(0) },
+		"JumpHash": func() { JumpHash(1, 0) },
+		"Bucket":   func() { IDs[0].id.Bucket(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
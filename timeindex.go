@@ -0,0 +1,119 @@
+package rid
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// TimeIndex is a map from ID to V that can also be queried and trimmed by
+// the time embedded in each ID, with no separate timestamp stored.
+//
+// Keys are held in ascending order alongside the map. As IDs are k-sorted,
+// keys generated in time order are appended cheaply; EvictBefore trims the
+// oldest keys in one step. Deleting arbitrary keys moves the keys that
+// follow.
+//
+// The zero value is an empty index ready to use. A TimeIndex is not safe for
+// concurrent use, nor may it be modified during iteration.
+type TimeIndex[V any] struct {
+	keys   []ID // ascending
+	values map[ID]V
+}
+
+// NewTimeIndex returns an empty TimeIndex.
+func NewTimeIndex[V any]() *TimeIndex[V] {
+	return &TimeIndex[V]{values: make(map[ID]V)}
+}
+
+// Len returns the number of entries in x.
+func (x *TimeIndex[V]) Len() int {
+	return len(x.keys)
+}
+
+// Put sets the value for id, replacing any existing value.
+func (x *TimeIndex[V]) Put(id ID, v V) {
+	if x.values == nil {
+		x.values = make(map[ID]V)
+	}
+	if _, ok := x.values[id]; !ok {
+		if n := len(x.keys); n == 0 || x.keys[n-1].Compare(id) < 0 {
+			x.keys = append(x.keys, id)
+		} else {
+			i, _ := slices.BinarySearchFunc(x.keys, id, Compare)
+			x.keys = slices.Insert(x.keys, i, id)
+		}
+	}
+	x.values[id] = v
+}
+
+// Get returns the value for id and whether it was present.
+func (x *TimeIndex[V]) Get(id ID) (V, bool) {
+	v, ok := x.values[id]
+	return v, ok
+}
+
+// Delete removes id, returning false if it was not present.
+func (x *TimeIndex[V]) Delete(id ID) bool {
+	if _, ok := x.values[id]; !ok {
+		return false
+	}
+
+	i, _ := slices.BinarySearchFunc(x.keys, id, Compare)
+	x.keys = slices.Delete(x.keys, i, i+1)
+	delete(x.values, id)
+
+	return true
+}
+
+// All returns an iterator over all entries in ascending ID order.
+func (x *TimeIndex[V]) All() iter.Seq2[ID, V] {
+	return x.between(0, len(x.keys))
+}
+
+// Range returns an iterator, in ascending ID order, over the entries whose
+// ID time t satisfies from <= t < to.
+func (x *TimeIndex[V]) Range(from, to time.Time) iter.Seq2[ID, V] {
+	return x.between(x.search(from), x.search(to))
+}
+
+func (x *TimeIndex[V]) between(i, j int) iter.Seq2[ID, V] {
+	return func(yield func(ID, V) bool) {
+		for _, id := range x.keys[i:max(i, j)] {
+			if !yield(id, x.values[id]) {
+				return
+			}
+		}
+	}
+}
+
+// EvictBefore removes the entries whose ID time is before t, returning the
+// number removed.
+func (x *TimeIndex[V]) EvictBefore(t time.Time) int {
+	n := x.search(t)
+	for _, id := range x.keys[:n] {
+		delete(x.values, id)
+	}
+	x.keys = slices.Delete(x.keys, 0, n)
+
+	return n
+}
+
+// search returns the index of the first key whose time is not before t.
+func (x *TimeIndex[V]) search(t time.Time) int {
+	// ID times are whole seconds: round t up to the next whole second
+	s := t.Unix()
+	if t.Nanosecond() > 0 {
+		s++
+	}
+
+	switch {
+	case s <= 0:
+		return 0
+	case s > maxTimestamp:
+		return len(x.keys)
+	}
+	i, _ := slices.BinarySearchFunc(x.keys, atTimestamp(uint32(s), 0x00), Compare)
+
+	return i
+}
@@ -0,0 +1,101 @@
+package rid
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimeIndex(t *testing.T) {
+	var x TimeIndex[string]
+	// sorted (ascending) should be IDs 2, 3, 0, 5, 4, 1
+	for i, v := range IDs {
+		x.Put(v.id, v.encoded)
+		if i == 0 {
+			x.Put(v.id, "replaced")
+		}
+	}
+	if x.Len() != len(IDs) {
+		t.Errorf("Len() = %d, want %d", x.Len(), len(IDs))
+	}
+	if got, ok := x.Get(IDs[0].id); !ok || got != "replaced" {
+		t.Errorf("Get() = %q, %v, want replaced, true", got, ok)
+	}
+	if _, ok := x.Get(New()); ok {
+		t.Error("Get() of absent ID = true")
+	}
+	want := []ID{IDList[2], IDList[3], IDList[0], IDList[5], IDList[4], IDList[1]}
+	var got []ID
+	for id := range x.All() {
+		got = append(got, id)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("\ngot %v\nwant %v\n", got, want)
+	}
+
+	if !x.Delete(IDs[5].id) || x.Delete(IDs[5].id) || x.Len() != len(IDs)-1 {
+		t.Error("Delete() did not remove exactly once")
+	}
+}
+
+func TestTimeIndexRange(t *testing.T) {
+	var x TimeIndex[int]
+	base := time.Unix(1672246995, 0)
+	for s := 0; s < 10; s++ {
+		for i := 0; i < 3; i++ {
+			x.Put(NewWithTime(base.Add(time.Duration(s)*time.Second)), s)
+		}
+	}
+	count := func(seq func(func(ID, int) bool)) (n int, secs []int) {
+		for _, s := range seq {
+			n++
+			if len(secs) == 0 || secs[len(secs)-1] != s {
+				secs = append(secs, s)
+			}
+		}
+		return n, secs
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []int
+	}{
+		{"whole seconds", base.Add(2 * time.Second), base.Add(5 * time.Second), []int{2, 3, 4}},
+		{"fractional from excludes its second", base.Add(2500 * time.Millisecond), base.Add(5 * time.Second), []int{3, 4}},
+		{"fractional to includes its second", base, base.Add(1500 * time.Millisecond), []int{0, 1}},
+		{"all", time.Unix(0, 0), time.Unix(maxTimestamp+1, 0), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"before epoch", time.Unix(-100, 0), base.Add(time.Second), []int{0}},
+		{"empty", base.Add(5 * time.Second), base.Add(2 * time.Second), nil},
+	}
+	for _, tt := range tests {
+		n, secs := count(x.Range(tt.from, tt.to))
+		if !slices.Equal(secs, tt.want) || n != 3*len(tt.want) {
+			t.Errorf("%s: Range() = %d entries from seconds %v, want seconds %v", tt.name, n, secs, tt.want)
+		}
+	}
+
+	if got := x.EvictBefore(base.Add(4 * time.Second)); got != 12 {
+		t.Errorf("EvictBefore() = %d, want 12", got)
+	}
+	if _, secs := count(x.All()); !slices.Equal(secs, []int{4, 5, 6, 7, 8, 9}) {
+		t.Errorf("after EvictBefore() seconds = %v", secs)
+	}
+	if x.Len() != 18 {
+		t.Errorf("Len() = %d, want 18", x.Len())
+	}
+	for id := range x.Range(base, base.Add(5*time.Second)) {
+		if _, ok := x.Get(id); !ok {
+			t.Errorf("Get(%v) missing", id)
+		}
+		break
+	}
+}
+
+func TestNewTimeIndex(t *testing.T) {
+	x := NewTimeIndex[struct{}]()
+	x.Put(IDs[0].id, struct{}{})
+	if x.EvictBefore(time.Now()) != 1 || x.Len() != 0 {
+		t.Error("EvictBefore(now) did not evict")
+	}
+}
@@ -0,0 +1,109 @@
+package rid
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TTLCache is a cache keyed by ID whose entries expire ttl after the time
+// embedded in their ID, suiting session and idempotency caches where an
+// entry's age is its key's age; no per-entry timestamp is stored.
+//
+// Expired entries are never returned. They are removed lazily when looked
+// up, by Sweep, or in the background by SweepEvery. The current time is
+// taken from an injectable clock, simplifying tests.
+//
+// A TTLCache is safe for concurrent use.
+type TTLCache[V any] struct {
+	ttl   time.Duration
+	now   func() time.Time
+	mu    sync.Mutex
+	index TimeIndex[V]
+}
+
+// NewTTLCache returns a TTLCache expiring entries ttl after their ID's time,
+// reading the current time from now; if now is nil, time.Now is used.
+func NewTTLCache[V any](ttl time.Duration, now func() time.Time) *TTLCache[V] {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &TTLCache[V]{ttl: ttl, now: now}
+}
+
+// expired returns true if id's entry has outlived the ttl at time now.
+func (c *TTLCache[V]) expired(id ID, now time.Time) bool {
+	return id.Time().Add(c.ttl).Before(now)
+}
+
+// Put sets the value for id. Entries for already expired IDs are not stored.
+func (c *TTLCache[V]) Put(id ID, v V) {
+	if c.expired(id, c.now()) {
+		return
+	}
+
+	c.mu.Lock()
+	c.index.Put(id, v)
+	c.mu.Unlock()
+}
+
+// Get returns the value for id and whether it was present and unexpired.
+func (c *TTLCache[V]) Get(id ID) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.index.Get(id)
+	if ok && c.expired(id, c.now()) {
+		c.index.Delete(id)
+		var zero V
+		return zero, false
+	}
+
+	return v, ok
+}
+
+// Delete removes id, returning false if it was not present.
+func (c *TTLCache[V]) Delete(id ID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.index.Delete(id)
+}
+
+// Len returns the number of entries held, including any expired entries not
+// yet swept.
+func (c *TTLCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.index.Len()
+}
+
+// Sweep removes expired entries, returning the number removed.
+func (c *TTLCache[V]) Sweep() int {
+	cutoff := c.now().Add(-c.ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.index.EvictBefore(cutoff)
+}
+
+// SweepEvery calls Sweep every interval until ctx is done. It blocks; run it
+// in its own goroutine:
+//
+//	go cache.SweepEvery(ctx, time.Minute)
+func (c *TTLCache[V]) SweepEvery(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			c.Sweep()
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package rid
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is an injectable clock for tests
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func TestTTLCache(t *testing.T) {
+	base := time.Unix(1672246995, 0)
+	clock := &fakeClock{t: base}
+	c := NewTTLCache[string](time.Minute, clock.Now)
+
+	old := NewWithTime(base.Add(-30 * time.Second))
+	fresh := NewWithTime(base)
+	c.Put(old, "old")
+	c.Put(fresh, "fresh")
+	c.Put(NewWithTime(base.Add(-2*time.Minute)), "expired") // not stored
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+
+	clock.Advance(30 * time.Second) // old is exactly ttl old: still live
+	if v, ok := c.Get(old); !ok || v != "old" {
+		t.Errorf("Get(old) = %q, %v, want old, true", v, ok)
+	}
+
+	clock.Advance(time.Second) // old expired; removed lazily by Get
+	if _, ok := c.Get(old); ok {
+		t.Error("Get() of expired entry = true")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", c.Len())
+	}
+	if v, ok := c.Get(fresh); !ok || v != "fresh" {
+		t.Errorf("Get(fresh) = %q, %v, want fresh, true", v, ok)
+	}
+
+	if !c.Delete(fresh) || c.Delete(fresh) {
+		t.Error("Delete() did not remove exactly once")
+	}
+}
+
+func TestTTLCacheSweep(t *testing.T) {
+	base := time.Unix(1672246995, 0)
+	clock := &fakeClock{t: base}
+	c := NewTTLCache[int](10*time.Second, clock.Now)
+	for s := 0; s < 10; s++ {
+		c.Put(NewWithTime(base.Add(time.Duration(-s)*time.Second)), s)
+	}
+
+	clock.Advance(5500 * time.Millisecond) // entries from -9s to -5s are older than 10s
+	if got := c.Sweep(); got != 5 {
+		t.Errorf("Sweep() = %d, want 5", got)
+	}
+	if c.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", c.Len())
+	}
+	if got := c.Sweep(); got != 0 {
+		t.Errorf("second Sweep() = %d, want 0", got)
+	}
+}
+
+func TestTTLCacheSweepEvery(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	c := NewTTLCache[int](time.Second, clock.Now)
+	c.Put(NewWithTime(clock.Now()), 1)
+	clock.Advance(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.SweepEvery(ctx, time.Millisecond)
+		close(done)
+	}()
+	for deadline := time.Now().Add(5 * time.Second); c.Len() > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("SweepEvery() did not sweep")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestTTLCacheDefaultClock(t *testing.T) {
+	c := NewTTLCache[int](time.Hour, nil)
+	id := New()
+	c.Put(id, 1)
+	if v, ok := c.Get(id); !ok || v != 1 {
+		t.Errorf("Get() = %v, %v, want 1, true", v, ok)
+	}
+}